@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit [<file_path>...]",
+	Short: "Reports who can read cataloged files.",
+	Long: `
+Users can report who can read each cataloged file. Readers are found 
+in secret resource policies, bucket policies, and KMS key policies. 
+CloudTrail GetSecretValue, GetParameter(s), and GetObject events in 
+the time window are counted per principal. The report ends with a 
+summary per principal.
+
+CloudTrail event history keeps 90 days of management events. S3 
+object reads are only recorded by trails logging data events.
+
+Examples: 
+
+$ stash audit
+$ stash audit -t prod --days 30
+$ stash audit --json > audit.json
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.AuditOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Files = filePaths
+		opts.JSON = viper.GetBool("json")
+
+		opts.End = time.Now().UTC()
+		opts.Start = opts.End.AddDate(0, 0, -viper.GetInt("days"))
+
+		if _, err := action.Audit(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	auditCmd.Flags().StringP("service", "s", "", "cloud service")
+	auditCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	auditCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	auditCmd.Flags().Int("days", 90, "days of CloudTrail events searched")
+	auditCmd.Flags().Bool("json", false, "write the report as JSON")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Manages the catalog format.",
+	Long: `
+Users can upgrade the catalog to the current format and print the 
+catalog JSON Schema used by editors to validate the catalog.
+`,
+}
+
+// catalogUpgradeCmd represents the catalog upgrade command
+var catalogUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrades the catalog to the current format.",
+	Long: `
+Users can upgrade the catalog, "stash.yml", written by an earlier 
+version of stash. Renamed fields and options are migrated to the 
+current format. Older catalogs are upgraded in memory by every 
+command; so, upgrading is only required to save the new format.
+
+Catalogs written in a newer format than this version of stash 
+reads are rejected.
+
+Example: 
+
+$ stash catalog upgrade
+`,
+	Args: cobra.NoArgs,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.Options{}
+
+		opts.Catalog = viper.GetString("file")
+
+		if _, err := action.UpgradeCatalog(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+// catalogSchemaCmd represents the catalog schema command
+var catalogSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Prints the catalog JSON Schema.",
+	Long: `
+Users can print the catalog JSON Schema. Editors supporting JSON 
+Schema validate the catalog as it is written.
+
+Example: 
+
+$ stash catalog schema > stash.schema.json
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		schema, err := catalog.Schema()
+		if err != nil {
+			m.Fatal(err)
+		}
+
+		fmt.Fprintln(os.Stdout, string(schema))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+
+	catalogCmd.AddCommand(catalogUpgradeCmd)
+	catalogCmd.AddCommand(catalogSchemaCmd)
+
+	catalogUpgradeCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+}
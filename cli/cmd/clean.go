@@ -49,6 +49,7 @@ $ stash clean config/dev/.env
 			Catalog: viper.GetString("file"),
 			Service: viper.GetString("service"),
 			Tags:    viper.GetStringSlice("tags"),
+			Query:   parseQuery(&m),
 			Files:   filePaths,
 		}, action.Dep{
 			Monitor: &m,
@@ -67,4 +68,5 @@ func init() {
 	cleanCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
 	cleanCmd.Flags().StringP("service", "s", "", "cloud service")
 	cleanCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	cleanCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
 }
@@ -0,0 +1,83 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manages the catalog context.",
+	Long: `
+Users can manage the catalog context used to prefix every 
+remote key.
+`,
+}
+
+// contextRenameCmd represents the context rename command
+var contextRenameCmd = &cobra.Command{
+	Use:   "rename <new-context>",
+	Short: "Renames the catalog context and its remote configuration.",
+	Long: `
+Users can rename the catalog context. Remote keys are prefixed 
+with the context; so, the remote configuration for every file is 
+copied to the new keys and verified before the catalog, "stash.yml", 
+is updated and the original remote keys are deleted. 
+
+No changes are made unless every file is copied.
+
+Example: 
+
+$ stash context rename slickapp-v2
+`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.RenameContextOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Context = args[0]
+
+		if err := action.RenameContext(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+
+	contextCmd.AddCommand(contextRenameCmd)
+
+	contextRenameCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+}
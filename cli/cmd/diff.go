@@ -0,0 +1,83 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [<file_path>...]",
+	Short: "Compares local files with their remote data.",
+	Long: `
+Users can list the keys a sync would add (+), remove (-), or change 
+(~) for each cataloged file. Values are never printed. Files that 
+are not key/value types are reported as changed.
+
+The command exits with a non-zero code when differences are found; 
+so, it can check for unsynced changes.
+
+Examples: 
+
+$ stash diff
+$ stash diff config/.env
+$ stash diff -t prod
+$ stash diff --recursive
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.Options{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Files = filePaths
+
+		if err := runCatalogs(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}, func(o action.Options, dep action.Dep) error {
+			_, err := action.Diff(o, dep)
+			return err
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	diffCmd.Flags().StringP("service", "s", "", "cloud service")
+	diffCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	diffCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	diffCmd.Flags().BoolP("recursive", "r", false, "run for every catalog under the working directory")
+}
@@ -62,8 +62,9 @@ $ stash edit config/dev/.env
 		o.Catalog = viper.GetString("file")
 		o.Service = viper.GetString("service")
 		o.Tags = viper.GetStringSlice("tags")
+		o.Query = parseQuery(&m)
 
-		filter := catalog.NewGetFilter(o.Files, o.Tags, o.Service)
+		filter := catalog.NewGetFilter(o.Files, o.Tags, o.Service, o.Query)
 
 		if filter.Empty() {
 			files, err := action.Browse(o.Catalog, dep)
@@ -118,4 +119,5 @@ func init() {
 	editCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
 	editCmd.Flags().StringP("service", "s", "", "cloud service")
 	editCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	editCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
 }
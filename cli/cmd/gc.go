@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// gcCmd represents the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Deletes orphaned configuration from a cloud service.",
+	Long: `
+Users can find remote secrets, parameters, and S3 objects under 
+the catalog context that are no longer referenced by the catalog, 
+"stash.yml". Orphans are left behind by failed syncs, renamed 
+paths, or deleted catalog entries.
+
+Each orphan is listed with the last modified date and only deleted 
+after it is selected or when --yes is set. S3 buckets referenced by the catalog are 
+searched along with any buckets specified.
+
+Examples: 
+
+$ stash gc --dry-run
+$ stash gc -s secrets-manager
+$ stash gc -b my-bucket
+$ stash gc -s parameter-store --yes
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.GCOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Warn = viper.GetBool("warn")
+		opts.Buckets = viper.GetStringSlice("bucket")
+		opts.DryRun = viper.GetBool("dry-run")
+		opts.Yes = viper.GetBool("yes")
+
+		if _, err := action.GC(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+
+	gcCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	gcCmd.Flags().StringP("service", "s", "", "cloud service")
+	gcCmd.Flags().StringSliceP("bucket", "b", []string{}, "additional S3 buckets")
+	gcCmd.Flags().Bool("dry-run", false, "list orphans without deleting")
+	gcCmd.Flags().BoolP("yes", "y", false, "delete every listed orphan without confirmation")
+	gcCmd.Flags().BoolP("warn", "w", true, "disable warnings")
+}
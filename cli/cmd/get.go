@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -43,14 +44,37 @@ data transformation.
 Examples: 
   stash get -t dev
   stash get config/dev/.env -o file 
+  stash get -o terraform --check
+  stash get -t dev -o ecs-container-def --task-def task-definition.json
+  stash get -t dev -o file --recursive
 
 Outputs:
   file                  	file    system	original file
-  terraform             	file    system	terraform scripts
+  terraform             	file    system	terraform scripts (module folder)
+  terraform-resources   	file    system	terraform resources for secrets, parameters, and objects (module folder)
+  cloudformation        	stdout  CloudFormation template (YAML)
+  pulumi                	stdout  Pulumi program (YAML)
+  iam-policy            	stdout  IAM policy (JSON) reading the remote data
   ecs-task-inject-json  	stdout  AWS ECS task definition secrets / envfile (JSON) (key/arn)
   ecs-task-inject-env   	stdout  AWS ECS task definition secrets / envfile (ENV) (key/arn)
   ecs-task-env          	stdout  AWS ECS task definition environment (JSON) (key/value)
+  ecs-container-def     	stdout  AWS ECS container definition environment, secrets, and environmentFiles (JSON)
+  k8s-secret            	stdout  Kubernetes Secret (YAML) (key per field)
+  k8s-secret-file       	stdout  Kubernetes Secret (YAML) (whole file)
+  k8s-configmap         	stdout  Kubernetes ConfigMap (YAML) (key per field)
+  k8s-configmap-file    	stdout  Kubernetes ConfigMap (YAML) (whole file)
+  k8s-external-secret   	stdout  External Secrets Operator ExternalSecret (YAML) (key/remote key)
+  compose-env           	stdout  docker-compose environment block (YAML)
+  compose-env-file      	stdout  docker-compose env_file
+  systemd               	stdout  systemd drop-in Environment= lines
+  systemd-env-file      	stdout  systemd EnvironmentFile
+  github-env            	stdout  GitHub Actions masks and $GITHUB_ENV commands (appended when set)
+  github-output         	stdout  GitHub Actions masks and $GITHUB_OUTPUT commands (appended when set)
+  gitlab-dotenv         	stdout  GitLab CI dotenv report artifact
+  template=<path>       	stdout  Go text/template rendered with the file values and metadata
   json                  	stdout  JSON object
+  env                   	stdout  .env file
+  yaml                  	stdout  flat YAML mapping
   terminal-export       	stdout  prepend "export " to each key/value pair (double quotes)
   terminal-export-literal   stdout  prepend "export " to each key/value pair (single quotes)
 `,
@@ -66,45 +90,132 @@ Outputs:
 		opts.Catalog = viper.GetString("file")
 		opts.Service = viper.GetString("service")
 		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
 		opts.Output = viper.GetString("output")
-		
-		downloaded, err := action.Get(opts, action.Dep{
+		opts.TerraformDir = viper.GetString("tf-dir")
+		opts.Check = viper.GetBool("check")
+		opts.TaskDefinition = viper.GetString("task-def")
+		opts.Container = viper.GetString("container")
+
+		if opts.Check && opts.Output != output.TypeTerraform && opts.Output != output.TypeTerraformResources {
+			m.Fatal(errors.New("check requires a terraform output"))
+		}
+
+		if len(opts.TaskDefinition) > 0 && opts.Output != output.TypeECSContainerDef {
+			m.Fatal(fmt.Errorf("task-def requires the %s output", output.TypeECSContainerDef))
+		}
+
+		// Workspace mode runs in each catalog's folder.
+		if len(opts.TaskDefinition) > 0 {
+			p, err := filepath.Abs(opts.TaskDefinition)
+			if err != nil {
+				m.Fatal(err)
+			}
+
+			opts.TaskDefinition = p
+		}
+
+		if err := runCatalogs(opts.Options, action.Dep{
 			Monitor: &m,
 			Stderr:  os.Stderr,
 			Stdout:  os.Stdout,
 			Stdin:   os.Stdin,
-		})
-
-		if err != nil {
+		}, func(o action.Options, dep action.Dep) error {
+			opts.Options = o
+			return getFiles(opts, dep)
+		}); err != nil {
 			m.Fatal(err)
 		}
+	},
+}
+
+// getFiles writes the downloaded files and sends the remaining outputs
+// to stdout.
+func getFiles(opts action.GetOpt, dep action.Dep) error {
+	downloaded, err := action.Get(opts, dep)
+	if err != nil {
+		return err
+	}
+
+	outdated := 0
 
-		pipe := bytes.Buffer{}
-		for _, df := range downloaded {
+	pipe := bytes.Buffer{}
+	for _, df := range downloaded {
+
+		switch df.Output {
+		case output.TypeFile:
+			if err := file.Write(df.Path, df.Data); err != nil {
+				return err
+			}
+		case output.TypeTerraform, output.TypeTerraformResources:
+			if opts.Check {
+				if existing, err := file.Read(df.Path); df.Remove || err != nil || !bytes.Equal(existing, df.Data) {
+					fmt.Fprintf(dep.Stderr, "- [%s] out of date\n", df.Path)
+					outdated++
+				}
+				continue
+			}
 
-			switch df.Output {
-			case output.TypeFile:
+			if df.Remove {
+				if err := os.Remove(df.Path); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := file.Write(df.Path, df.Data); err != nil {
+				return err
+			}
+		case output.TypeECSContainerDef:
+			if len(opts.TaskDefinition) > 0 {
 				if err := file.Write(df.Path, df.Data); err != nil {
-					m.Fatal(err)
+					return err
 				}
-			case output.TypeTerraform:
-				tfFilePath := fmt.Sprintf("%s/%s-%s.tf", filepath.Dir(df.Path), df.Service, file.DashIt(filepath.Base(df.Path)))
-				if err := file.Write(tfFilePath, df.Data); err != nil {
-					m.Fatal(err)
+				continue
+			}
+
+			df.Data = append(df.Data, []byte("\n")...)
+
+			if _, err := pipe.Write(df.Data); err != nil {
+				return err
+			}
+		case output.TypeGitHubEnv, output.TypeGitHubOutput:
+			// Inside a runner, masks are sent to stdout and the
+			// values are appended to the runner file.
+			if path := os.Getenv(output.GitHubFileVariable(df.Output)); len(path) > 0 {
+				masks, commands := output.SplitGitHubCommands(df.Data)
+
+				if err := file.Append(path, commands); err != nil {
+					return err
 				}
-			default:
-				df.Data = append(df.Data, []byte("\n")...)
 
-				if _, err := pipe.Write(df.Data); err != nil {
-					m.Fatal(err)
+				if _, err := pipe.Write(masks); err != nil {
+					return err
 				}
+				continue
 			}
-		}
 
-		if _, err := pipe.WriteTo(os.Stdout); err != nil {
-			m.Fatal(err)
+			if _, err := pipe.Write(df.Data); err != nil {
+				return err
+			}
+		default:
+			df.Data = append(df.Data, []byte("\n")...)
+
+			if _, err := pipe.Write(df.Data); err != nil {
+				return err
+			}
 		}
-	},
+	}
+
+	if _, err := pipe.WriteTo(dep.Stdout); err != nil {
+		return err
+	}
+
+	if outdated > 0 {
+		return fmt.Errorf("%d terraform file(s) out of date, run without --check to update", outdated)
+	}
+
+	return nil
 }
 
 func init() {
@@ -114,6 +225,12 @@ func init() {
 	getCmd.Flags().StringP("output", "o", "original", "output format")
 	getCmd.Flags().StringP("service", "s", "", "cloud service")
 	getCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	getCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	getCmd.Flags().String("tf-dir", "", "terraform module folder (default: terraform)")
+	getCmd.Flags().Bool("check", false, "fail when generated terraform is out of date")
+	getCmd.Flags().String("task-def", "", "ecs task definition file patched with the container definition")
+	getCmd.Flags().String("container", "", "container name patched in the task definition")
+	getCmd.Flags().BoolP("recursive", "r", false, "run for every catalog under the working directory")
 
 	viper.SetDefault("output", output.TypeOriginal)
 }
@@ -0,0 +1,102 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// grantCmd represents the grant command
+var grantCmd = &cobra.Command{
+	Use:   "grant <role-or-user-arn> [<file_path>...]",
+	Short: "Grants an IAM role or user read access to cataloged files.",
+	Long: `
+Users can compute the least privilege IAM policy reading the matching 
+files. The policy allows secretsmanager:GetSecretValue on secrets, 
+ssm:GetParameters on parameters, s3:GetObject on objects, and 
+kms:Decrypt on the keys encrypting them.
+
+The policy is sent to stdout unless it is attached to the role or 
+user as an inline policy. Stash bucket policies restricting readers 
+can be updated to include the role or user.
+
+Examples: 
+
+$ stash grant arn:aws:iam::123456789012:role/slickapp-task -t prod
+$ stash grant arn:aws:iam::123456789012:role/slickapp-task -t prod --attach
+$ stash grant arn:aws:iam::123456789012:role/slickapp-task -s s3 --bucket-policy
+`,
+	Args: cobra.MinimumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.GrantOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Principal = args[0]
+		opts.Files = args[1:]
+		opts.Attach = viper.GetBool("attach")
+		opts.PolicyName = viper.GetString("name")
+		opts.BucketPolicy = viper.GetBool("bucket-policy")
+
+		p, err := action.Grant(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		})
+		if err != nil {
+			m.Fatal(err)
+		}
+
+		if opts.Attach || opts.BucketPolicy {
+			return
+		}
+
+		b, err := json.MarshalIndent(p, "", "    ")
+		if err != nil {
+			m.Fatal(err)
+		}
+
+		fmt.Fprintln(os.Stdout, string(b))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grantCmd)
+
+	grantCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	grantCmd.Flags().StringP("service", "s", "", "cloud service")
+	grantCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	grantCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	grantCmd.Flags().Bool("attach", false, "attach the policy to the role or user")
+	grantCmd.Flags().String("name", "", "inline policy name (default stash-<context>)")
+	grantCmd.Flags().Bool("bucket-policy", false, "add the role or user to Stash bucket policies")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Catalogs existing configuration from a cloud service.",
+	Long: `
+Users can catalog configuration that already exists in a cloud 
+service. Secrets, parameters, and S3 objects are discovered by 
+key prefix or remote tags and added to the catalog, "stash.yml".
+
+This command never modifies the cloud service.
+
+Examples: 
+
+$ stash import -s secrets-manager -p my-app/
+$ stash import -s secrets-manager --remote-tags team=devops
+$ stash import -s parameter-store -p /my-app/config
+$ stash import -s s3 -b my-bucket -p my-app/
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.ImportOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Context = viper.GetString("context")
+		opts.Prefix = viper.GetString("prefix")
+		opts.Bucket = viper.GetString("bucket")
+		opts.RemoteTags = viper.GetStringMapString("remote-tags")
+
+		if _, err := action.Import(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	importCmd.Flags().StringP("context", "c", "", "cloud storage key prefix")
+	importCmd.Flags().StringP("service", "s", "", "cloud service")
+	importCmd.Flags().StringP("prefix", "p", "", "remote key prefix (default: context)")
+	importCmd.Flags().StringP("bucket", "b", "", "S3 bucket")
+	importCmd.Flags().StringToString("remote-tags", map[string]string{}, "remote resource tags")
+	importCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+
+	importCmd.MarkFlagRequired("service")
+}
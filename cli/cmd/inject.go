@@ -39,6 +39,7 @@ that supports key/value pairs.
 Example: 
 
 $ stash inject config/dev/.env
+$ stash inject config/dev/.env -q 'key:slickapp/dev/**'
 `,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlags(cmd.Flags())
@@ -50,6 +51,7 @@ $ stash inject config/dev/.env
 			Files:   filePaths,
 			Service: viper.GetString("service"),
 			Output:  viper.GetString("output"),
+			Query:   parseQuery(&m),
 		}, action.Dep{
 			Monitor: &m,
 			Stderr:  os.Stderr,
@@ -89,6 +91,7 @@ func init() {
 
 	injectCmd.Flags().StringP("output", "o", "", "file output format")
 	injectCmd.Flags().StringP("service", "s", "", "cloud service")
+	injectCmd.Flags().StringP("query", "q", "", "filter expression limiting the injected tokens (e.g. key:app/prod/**)")
 
 	injectCmd.MarkFlagRequired("service")
 
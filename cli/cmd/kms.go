@@ -0,0 +1,245 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// kmsCmd represents the kms command
+var kmsCmd = &cobra.Command{
+	Use:   "kms",
+	Short: "Manages the KMS keys encrypting configuration.",
+	Long: `
+Users can create KMS keys, grant or revoke decrypt access to IAM 
+roles and users, list which files use each key, and re-encrypt 
+files under a new key.
+`,
+}
+
+// kmsCreateCmd represents the kms create command
+var kmsCreateCmd = &cobra.Command{
+	Use:   "create <alias>",
+	Short: "Creates a KMS key for encrypting configuration.",
+	Long: `
+Users can create a KMS key with key admins and users. Admins can 
+manage and use the key while users can only decrypt. The current 
+user is always an admin. The new key id is sent to stdout.
+
+Examples: 
+
+$ stash kms create alias/slickapp
+$ stash kms create slickapp --user arn:aws:iam::123456789012:role/slickapp-task
+`,
+	Args: cobra.ExactArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.KMSCreateOpt{}
+
+		opts.Alias = args[0]
+		opts.Admins = viper.GetStringSlice("admin")
+		opts.Users = viper.GetStringSlice("user")
+
+		keyID, err := action.KMSCreate(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		})
+		if err != nil {
+			m.Fatal(err)
+		}
+
+		fmt.Fprintln(os.Stdout, keyID)
+	},
+}
+
+// kmsGrantCmd represents the kms grant command
+var kmsGrantCmd = &cobra.Command{
+	Use:   "grant <role-or-user-arn> [<file_path>...]",
+	Short: "Grants decrypt access to the keys used by cataloged files.",
+	Long: `
+Users can grant an IAM role or user decrypt access to the KMS keys 
+encrypting the matching files. Key policies created by Stash are 
+updated in place. Other key policies receive a separate statement. 
+AWS managed keys cannot be changed and are skipped.
+
+Examples: 
+
+$ stash kms grant arn:aws:iam::123456789012:role/slickapp-task
+$ stash kms grant arn:aws:iam::123456789012:role/slickapp-task -t prod
+`,
+	Args: cobra.MinimumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		runKMSGrant(args, false)
+	},
+}
+
+// kmsRevokeCmd represents the kms revoke command
+var kmsRevokeCmd = &cobra.Command{
+	Use:   "revoke <role-or-user-arn> [<file_path>...]",
+	Short: "Revokes decrypt access to the keys used by cataloged files.",
+	Long: `
+Users can revoke decrypt access previously granted to an IAM role 
+or user on the KMS keys encrypting the matching files.
+
+Example: 
+
+$ stash kms revoke arn:aws:iam::123456789012:role/slickapp-task
+`,
+	Args: cobra.MinimumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		runKMSGrant(args, true)
+	},
+}
+
+// kmsUsageCmd represents the kms usage command
+var kmsUsageCmd = &cobra.Command{
+	Use:   "usage [<file_path>...]",
+	Short: "Lists the KMS keys used by cataloged files.",
+	Long: `
+Users can list the KMS keys encrypting each cataloged file.
+
+Example: 
+
+$ stash kms usage
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.Options{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Files = filePaths
+
+		if err := action.KMSUsage(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+// kmsReencryptCmd represents the kms reencrypt command
+var kmsReencryptCmd = &cobra.Command{
+	Use:   "reencrypt <key-id> [<file_path>...]",
+	Short: "Re-encrypts cataloged files under a new KMS key.",
+	Long: `
+Users can store the matching files again encrypted by a different 
+KMS key. The key can be a key id, ARN, or existing alias. Each 
+file is downloaded, synced with the new key, and the catalog is 
+updated.
+
+Examples: 
+
+$ stash kms reencrypt alias/slickapp
+$ stash kms reencrypt alias/slickapp config/prod/.env
+`,
+	Args: cobra.MinimumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.KMSReencryptOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.KeyID = args[0]
+		opts.Files = args[1:]
+
+		if _, err := action.KMSReencrypt(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func runKMSGrant(args []string, revoke bool) {
+	m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+	opts := action.KMSGrantOpt{}
+
+	opts.Catalog = viper.GetString("file")
+	opts.Service = viper.GetString("service")
+	opts.Tags = viper.GetStringSlice("tags")
+	opts.Query = parseQuery(&m)
+	opts.Principal = args[0]
+	opts.Files = args[1:]
+	opts.Revoke = revoke
+
+	if _, err := action.KMSGrant(opts, action.Dep{
+		Monitor: &m,
+		Stderr:  os.Stderr,
+		Stdin:   os.Stdin,
+		Stdout:  os.Stdout,
+	}); err != nil {
+		m.Fatal(err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(kmsCmd)
+
+	kmsCmd.AddCommand(kmsCreateCmd)
+	kmsCmd.AddCommand(kmsGrantCmd)
+	kmsCmd.AddCommand(kmsRevokeCmd)
+	kmsCmd.AddCommand(kmsUsageCmd)
+	kmsCmd.AddCommand(kmsReencryptCmd)
+
+	kmsCreateCmd.Flags().StringSlice("admin", []string{}, "IAM role or user ARNs allowed to manage the key")
+	kmsCreateCmd.Flags().StringSlice("user", []string{}, "IAM role or user ARNs allowed to decrypt")
+
+	for _, c := range []*cobra.Command{kmsGrantCmd, kmsRevokeCmd, kmsUsageCmd, kmsReencryptCmd} {
+		c.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+		c.Flags().StringP("service", "s", "", "cloud service")
+		c.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+		c.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	}
+}
@@ -36,9 +36,10 @@ the catalog, "stash.yml".
 This command does not verify the cloud service
 is in sync with the local files.
 
-Example: 
+Examples: 
 
 $ stash list -t dev
+$ stash list -t dev --recursive
 `,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlags(cmd.Flags())
@@ -46,17 +47,18 @@ $ stash list -t dev
 	Run: func(cmd *cobra.Command, filePaths []string) {
 		m := monitor.New(os.Stderr, viper.GetBool("logs"))
 
-		if err := action.List(action.Options{
+		if err := runCatalogs(action.Options{
 			Catalog: viper.GetString("file"),
 			Service: viper.GetString("service"),
 			Tags:    viper.GetStringSlice("tags"),
+			Query:   parseQuery(&m),
 			Files:   filePaths,
 		}, action.Dep{
 			Monitor: &m,
 			Stdin:   os.Stdin,
 			Stderr:  os.Stderr,
 			Stdout:  os.Stdout,
-		}); err != nil {
+		}, action.List); err != nil {
 			m.Fatal(err)
 		}
 	},
@@ -68,4 +70,6 @@ func init() {
 	listCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
 	listCmd.Flags().StringP("service", "s", "", "cloud service")
 	listCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	listCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	listCmd.Flags().BoolP("recursive", "r", false, "run for every catalog under the working directory")
 }
@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Moves configuration to a different cloud service.",
+	Long: `
+Users can move configuration files from one cloud service to 
+another. Each file is downloaded from the current service, synced 
+to the new service, and verified before the catalog is updated. 
+The original remote data is kept unless purged.
+
+Examples: 
+
+$ stash migrate config/dev/.env --to parameter-store
+$ stash migrate -t dev --to secrets-manager --purge
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.MigrateOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Files = filePaths
+		opts.To = viper.GetString("to")
+		opts.Purge = viper.GetBool("purge")
+
+		if _, err := action.Migrate(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	migrateCmd.Flags().StringP("service", "s", "", "current cloud service")
+	migrateCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	migrateCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	migrateCmd.Flags().String("to", "", "new cloud service")
+	migrateCmd.Flags().Bool("purge", false, "purge original remote data after verification")
+
+	migrateCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// mvCmd represents the mv command
+var mvCmd = &cobra.Command{
+	Use:   "mv <old-path> <new-path>",
+	Short: "Moves a cataloged file and its remote configuration.",
+	Long: `
+Users can move a cataloged file to a new local path. Remote keys 
+are derived from the path; so, the remote configuration is copied 
+to the new keys and verified before the catalog, "stash.yml", is 
+updated and the original remote keys are deleted.
+
+Example: 
+
+$ stash mv config/dev/.env config/development/.env
+
+With a filter expression, every matching file under the old folder 
+is moved to the new folder.
+
+$ stash mv config/dev config/development -q 'tag:api'
+`,
+	Args: cobra.ExactArgs(2),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.MoveOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Query = parseQuery(&m)
+		opts.From = args[0]
+		opts.To = args[1]
+
+		if err := action.Move(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mvCmd)
+
+	mvCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	mvCmd.Flags().StringP("query", "q", "", "filter expression moving every matching file under the old folder (e.g. tag:prod and not type:json)")
+}
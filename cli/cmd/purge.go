@@ -50,6 +50,7 @@ $ stash purge config/dev/.env
 			Catalog: viper.GetString("file"),
 			Service: viper.GetString("service"),
 			Tags:    viper.GetStringSlice("tags"),
+			Query:   parseQuery(&m),
 			Files:   filePaths,
 			Warn:    viper.GetBool("warn"),
 		}, action.Dep{
@@ -79,5 +80,6 @@ func init() {
 	purgeCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
 	purgeCmd.Flags().StringP("service", "s", "", "cloud service")
 	purgeCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	purgeCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
 	purgeCmd.Flags().BoolP("warn", "w", true, "disable warnings")
 }
@@ -0,0 +1,38 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/viper"
+)
+
+// parseQuery parses the filter expression. Commands without a query
+// filter every file.
+func parseQuery(m *monitor.Monitor) *catalog.Query {
+	expr := viper.GetString("query")
+	if len(expr) == 0 {
+		return nil
+	}
+
+	q, err := catalog.ParseQuery(expr)
+	if err != nil {
+		m.Fatal(err)
+	}
+
+	return q
+}
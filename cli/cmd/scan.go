@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan [<file_path>...]",
+	Short: "Scans for secrets that are not cataloged.",
+	Long: `
+Users can scan the working directory for files containing likely 
+secrets (AWS keys, private keys, and high entropy strings) that are 
+not cataloged. Cataloged files found locally must be ignored by git.
+Files ignored by git are not scanned. Lines containing "stash:allow" 
+are skipped.
+
+The command exits with a non-zero code when issues are found; so, 
+it can be used as a pre-commit hook. Hooks can pass the staged 
+files to only scan those files.
+
+Examples: 
+
+$ stash scan
+$ stash scan -e '^testdata/'
+$ stash scan $(git diff --cached --name-only --diff-filter=ACM)
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.ScanOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Exclude = viper.GetStringSlice("exclude")
+		opts.Files = filePaths
+
+		if _, err := action.Scan(opts, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	scanCmd.Flags().StringSliceP("exclude", "e", []string{}, "regular expressions matching paths not scanned")
+}
@@ -39,9 +39,13 @@ to access the stash.
 Local configuration files should *NEVER* be checked into source 
 control when they contain secrets.
 
-Example: 
+In workspace mode, --recursive, cataloged files are synced for 
+every catalog under the working directory.
+
+Examples: 
 
 $ stash sync config/dev/.env
+$ stash sync -t dev --recursive
 `,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlags(cmd.Flags())
@@ -55,14 +59,19 @@ $ stash sync config/dev/.env
 		opts.Catalog = viper.GetString("file")
 		opts.Service = viper.GetString("service")
 		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
 		opts.Context = viper.GetString("context")
+		opts.Gitignore = viper.GetBool("gitignore")
 
-		if err := action.Sync(opts,
+		if err := runCatalogs(opts.Options,
 			action.Dep{
 				Monitor: &m,
 				Stderr:  os.Stderr,
 				Stdin:   os.Stdin,
 				Stdout:  os.Stdout,
+			}, func(o action.Options, dep action.Dep) error {
+				opts.Options = o
+				return action.Sync(opts, dep)
 			}); err != nil {
 			m.Fatal(err)
 		}
@@ -78,4 +87,7 @@ func init() {
 	syncCmd.Flags().StringP("context", "c", "", "cloud storage key prefix")
 	syncCmd.Flags().StringP("service", "s", "", "cloud service")
 	syncCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	syncCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	syncCmd.Flags().BoolP("recursive", "r", false, "run for every catalog under the working directory")
+	syncCmd.Flags().Bool("gitignore", true, "add new files to the nearest .gitignore")
 }
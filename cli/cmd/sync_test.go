@@ -33,6 +33,9 @@ func TestMain(m *testing.M) {
 	os.Setenv("STASH_CONTEXT", "stash-test")
 	os.Setenv("STASH_WARN", "false")
 
+	// Tests run in the repository; so, its .gitignore is left as is.
+	os.Setenv("STASH_GITIGNORE", "false")
+
 	os.Exit(m.Run())
 }
 
@@ -51,6 +51,7 @@ $ stash tag config/dev/.env -t app
 		opts.Catalog = viper.GetString("file")
 		opts.Service = viper.GetString("service")
 		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
 		opts.Add = viper.GetStringSlice("add")
 		opts.Delete = viper.GetStringSlice("delete")
 
@@ -71,6 +72,7 @@ func init() {
 	tagCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
 	tagCmd.Flags().StringP("service", "s", "", "cloud service")
 	tagCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	tagCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
 	tagCmd.Flags().StringSliceP("add", "a", []string{}, "remove tag")
 	tagCmd.Flags().StringSliceP("delete", "d", []string{}, "delete tag")
 }
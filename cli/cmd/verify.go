@@ -0,0 +1,88 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/dabblebox/stash/component/action"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [<file_path>...]",
+	Short: "Verifies cataloged files against their services.",
+	Long: `
+Users can verify every cataloged key exists remotely, is encrypted 
+with the configured KMS key, and can be decrypted. Missing and 
+undecryptable keys are errors. Remote keys not in the catalog are 
+warnings. The --local flag also compares the remote data to the 
+local files.
+
+The command exits with a non-zero code when errors are found; so, 
+it can gate deployments.
+
+Examples: 
+
+$ stash verify
+$ stash verify -t prod
+$ stash verify --local
+$ stash verify -t prod --recursive
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlags(cmd.Flags())
+	},
+	Run: func(cmd *cobra.Command, filePaths []string) {
+		m := monitor.New(os.Stderr, viper.GetBool("logs"))
+
+		opts := action.VerifyOpt{}
+
+		opts.Catalog = viper.GetString("file")
+		opts.Service = viper.GetString("service")
+		opts.Tags = viper.GetStringSlice("tags")
+		opts.Query = parseQuery(&m)
+		opts.Files = filePaths
+		opts.Local = viper.GetBool("local")
+
+		if err := runCatalogs(opts.Options, action.Dep{
+			Monitor: &m,
+			Stderr:  os.Stderr,
+			Stdin:   os.Stdin,
+			Stdout:  os.Stdout,
+		}, func(o action.Options, dep action.Dep) error {
+			opts.Options = o
+			_, err := action.Verify(opts, dep)
+			return err
+		}); err != nil {
+			m.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringP("file", "f", catalog.DefaultName, "catalog name")
+	verifyCmd.Flags().StringP("service", "s", "", "cloud service")
+	verifyCmd.Flags().StringSliceP("tags", "t", []string{}, "tagging for quick file reference")
+	verifyCmd.Flags().StringP("query", "q", "", "filter expression (e.g. tag:prod and not type:json)")
+	verifyCmd.Flags().Bool("local", false, "compare the remote data to the local files")
+	verifyCmd.Flags().BoolP("recursive", "r", false, "run for every catalog under the working directory")
+}
@@ -0,0 +1,160 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/service"
+	"github.com/dabblebox/stash/component/service/aws/audit"
+)
+
+// AuditOpt ...
+type AuditOpt struct {
+	Options
+
+	// Start and End bound the CloudTrail events searched.
+	Start time.Time
+	End   time.Time
+
+	// JSON writes the report as JSON instead of text.
+	JSON bool
+}
+
+// AuditFile lists the readers of a cataloged file.
+type AuditFile struct {
+	Path    string         `json:"path"`
+	Service string         `json:"service"`
+	Readers []audit.Reader `json:"readers"`
+}
+
+// AuditReport lists the readers of each file and summarizes them per
+// principal.
+type AuditReport struct {
+	Start      time.Time      `json:"start"`
+	End        time.Time      `json:"end"`
+	Files      []AuditFile    `json:"files"`
+	Principals []audit.Reader `json:"principals"`
+}
+
+// Audit reports who can read the matching catalog files through
+// resource and key policies and who read them during the time window.
+func Audit(opt AuditOpt, dep Dep) (AuditReport, error) {
+	report := AuditReport{
+		Start:      opt.Start,
+		End:        opt.End,
+		Files:      []AuditFile{},
+		Principals: []audit.Reader{},
+	}
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return report, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	if len(targetFiles) == 0 {
+		return report, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	auditor, err := service.NewAuditor(opt.Start, opt.End)
+	if err != nil {
+		return report, err
+	}
+
+	//-------------------------------------
+	//- Audit Files
+	//-------------------------------------
+	fmt.Fprintf(dep.Stderr, "\n%s (%s - %s)\n\n", bold("auditing"), opt.Start.Format("2006-01-02"), opt.End.Format("2006-01-02"))
+
+	paths := []string{}
+	files := map[string]catalog.File{}
+	for _, f := range targetFiles {
+		paths = append(paths, f.Path)
+		files[f.Path] = f
+	}
+	sort.Strings(paths)
+
+	all := [][]audit.Reader{}
+
+	for _, path := range paths {
+		f := files[path]
+
+		fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(f.Path))
+
+		readers, err := auditor.Readers(service.AuditResources(f.Service, f.Keys, f.Options))
+		if err != nil {
+			dep.Monitor.FileError(err)
+			continue
+		}
+
+		report.Files = append(report.Files, AuditFile{
+			Path:    f.Path,
+			Service: f.Service,
+			Readers: readers,
+		})
+
+		all = append(all, readers)
+	}
+
+	report.Principals = audit.Summarize(all...)
+
+	//-------------------------------------
+	//- Write Report
+	//-------------------------------------
+	if opt.JSON {
+		b, err := marshalJSON(report, "    ")
+		if err != nil {
+			return report, err
+		}
+
+		dep.Stdout.Write(b)
+	} else {
+		writeAuditReport(report, dep)
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) audited\n\n", len(report.Files))
+
+	if len(dep.Monitor.Errors) > 0 {
+		return report, errors.New("audit errors detected")
+	}
+
+	return report, nil
+}
+
+func writeAuditReport(report AuditReport, dep Dep) {
+	for _, f := range report.Files {
+		fmt.Fprintf(dep.Stdout, "\n%s (%s)\n", bold(f.Path), f.Service)
+
+		for _, r := range f.Readers {
+			fmt.Fprintf(dep.Stdout, "  - %s\n", formatReader(r))
+		}
+	}
+
+	fmt.Fprintf(dep.Stdout, "\n%s\n", bold("Principals"))
+
+	for _, r := range report.Principals {
+		fmt.Fprintf(dep.Stdout, "  - %s\n", formatReader(r))
+	}
+
+	fmt.Fprintln(dep.Stdout)
+}
+
+func formatReader(r audit.Reader) string {
+	s := fmt.Sprintf("%s [%s]", r.Principal, strings.Join(r.Sources, ", "))
+
+	if r.LastRead != nil {
+		s = fmt.Sprintf("%s %d read(s), last %s", s, r.Reads, r.LastRead.Format(time.RFC3339))
+	}
+
+	return s
+}
@@ -0,0 +1,47 @@
+package action
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"gopkg.in/yaml.v2"
+)
+
+// UpgradeCatalog migrates the catalog file to the current format. The
+// original format is returned.
+func UpgradeCatalog(opt Options, dep Dep) (int, error) {
+	data, err := file.Read(opt.Catalog)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("%s: %s", opt.Catalog, os.ErrNotExist)
+		}
+
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	data, from, err := catalog.Migrate(data)
+	if err != nil {
+		return from, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	if from == catalog.FormatVersion {
+		fmt.Fprintf(dep.Stderr, "\n%s is format %d, no upgrade needed\n\n", bold(opt.Catalog), from)
+		return from, nil
+	}
+
+	// Fields unknown to the current format would be dropped when saved.
+	c := catalog.Catalog{}
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return from, fmt.Errorf("%s: %s, run: stash validate", opt.Catalog, err)
+	}
+
+	if err := catalog.Save(opt.Catalog, c); err != nil {
+		return from, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%s upgraded from format %d to %d\n\n", bold(opt.Catalog), from, catalog.FormatVersion)
+
+	return from, nil
+}
@@ -24,7 +24,7 @@ func Clean(opt Options, dep Dep) error {
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
@@ -0,0 +1,118 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// Diff compares each local file with its remote data and lists the
+// keys a sync would add (+), remove (-), and change (~). Files that
+// cannot be compared by key are reported as changed. The number of
+// files with differences is returned.
+func Diff(opt Options, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	if len(targetFiles) == 0 {
+		return 0, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	differ := 0
+
+	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s (comparing)\n\n", bold(service.Name(serviceKey)))
+
+		remote, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintln(dep.Stderr, formatFileSyncText(cf.ResolveContext(c.Context), key, cf.Path, service.FormatObjectKey(cf.ResolveContext(c.Context), cf.Path, remote)))
+
+			local, err := file.Read(cf.Path)
+			switch {
+			case os.IsNotExist(err):
+				dep.Monitor.FileWarn("local file not found")
+				differ++
+				continue
+			case err != nil:
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			if len(cf.Keys) == 0 {
+				dep.Monitor.FileWarn("keys are empty, the file has not been synced")
+				differ++
+				continue
+			}
+
+			stashFile, err := cf.ToServiceModel(c.Context, key, remote, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			df, err := remote.Download(stashFile, output.TypeOriginal)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			if output.Equal(cf.Type, df.Data, local) {
+				continue
+			}
+
+			differ++
+
+			changes, err := output.Changes(cf.Type, df.Data, local)
+			if err != nil {
+				fmt.Fprintf(dep.Stderr, "  ~ %s\n", cf.Path)
+				continue
+			}
+
+			for _, ch := range changes {
+				fmt.Fprintf(dep.Stderr, "  %s\n", ch)
+			}
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d of %d file(s) differ\n\n", differ, len(targetFiles))
+
+	if len(dep.Monitor.Errors) > 0 {
+		return differ, errors.New("compare errors detected")
+	}
+
+	if differ > 0 {
+		return differ, errors.New("differences detected")
+	}
+
+	return differ, nil
+}
@@ -0,0 +1,379 @@
+package action
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/output"
+)
+
+// ecsConfigFields are the container definition fields replaced when
+// patching a task definition.
+var ecsConfigFields = []string{"environment", "secrets", "environmentFiles"}
+
+// mergeContainerDefinitions merges the container definition snippets
+// from each service into a single snippet.
+func mergeContainerDefinitions(files []DownloadedFile) (output.ECSContainerDefinition, error) {
+	def := output.ECSContainerDefinition{}
+
+	for _, f := range files {
+		d := output.ECSContainerDefinition{}
+		if err := json.Unmarshal(f.Data, &d); err != nil {
+			return def, fmt.Errorf("%s: %s", f.Path, err)
+		}
+
+		def.Merge(d)
+	}
+
+	return def, nil
+}
+
+// ecsEnvironmentLabel prefixes the docker labels listing the plain
+// text variables written for a remote key. Plain text variables do not
+// reference their parameter; so, the names are tracked by the labels.
+const ecsEnvironmentLabel = "stash.environment:"
+
+// patchTaskDefinition replaces the configuration fields of a container
+// in an existing task definition with the downloaded files. Other
+// fields and their order are left untouched. When a container is not
+// named, the task definition must contain exactly one container.
+// Secrets and environment files referencing the remote keys, and the
+// plain text variables previously written for them, are replaced; so,
+// removed keys are removed from the container.
+func patchTaskDefinition(path, container string, files []DownloadedFile) (DownloadedFile, error) {
+	def, err := mergeContainerDefinitions(files)
+	if err != nil {
+		return DownloadedFile{}, err
+	}
+
+	data, err := file.Read(path)
+	if err != nil {
+		return DownloadedFile{}, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return DownloadedFile{}, fmt.Errorf("%s: %s", path, err)
+	}
+
+	taskDef, ok := v.(orderedObject)
+	if !ok {
+		return DownloadedFile{}, fmt.Errorf("%s: task definition object required", path)
+	}
+
+	// Support both the registered task definition and the
+	// describe-task-definition response.
+	if td, ok := taskDef.get("taskDefinition").(orderedObject); ok {
+		taskDef = td
+	}
+
+	containers, ok := taskDef.get("containerDefinitions").([]interface{})
+	if !ok {
+		return DownloadedFile{}, fmt.Errorf("%s: containerDefinitions not found", path)
+	}
+
+	target := -1
+	for i, c := range containers {
+		cd, ok := c.(orderedObject)
+		if !ok {
+			continue
+		}
+
+		if len(container) == 0 && len(containers) == 1 {
+			target = i
+		} else if name, _ := cd.get("name").(string); len(container) > 0 && name == container {
+			target = i
+		}
+	}
+
+	if target == -1 {
+		if len(container) == 0 {
+			return DownloadedFile{}, fmt.Errorf("%s: container name required, task definition has %d containers", path, len(containers))
+		}
+
+		return DownloadedFile{}, fmt.Errorf("%s: container %s not found", path, container)
+	}
+
+	cd := containers[target].(orderedObject)
+
+	existing := output.ECSContainerDefinition{}
+	if err := remarshal(cd, &existing); err != nil {
+		return DownloadedFile{}, fmt.Errorf("%s: %s", path, err)
+	}
+
+	labels, _ := cd.get("dockerLabels").(orderedObject)
+	hasLabels := cd.get("dockerLabels") != nil
+
+	remoteKeys := []string{}
+	for _, f := range files {
+		if len(f.RemoteKeys) == 0 {
+			continue
+		}
+
+		remoteKeys = append(remoteKeys, f.RemoteKeys...)
+
+		label := ecsEnvironmentLabel + f.RemoteKeys[0]
+		if names, ok := labels.get(label).(string); ok {
+			existing.RemoveEnvironment(strings.Split(names, ","))
+		}
+
+		d := output.ECSContainerDefinition{}
+		if err := json.Unmarshal(f.Data, &d); err != nil {
+			return DownloadedFile{}, fmt.Errorf("%s: %s", f.Path, err)
+		}
+
+		names := []string{}
+		for _, e := range d.Environment {
+			names = append(names, e.Name)
+		}
+		sort.Strings(names)
+
+		var value interface{}
+		if len(names) > 0 {
+			value = strings.Join(names, ",")
+		}
+
+		labels = labels.set(label, value)
+	}
+
+	existing.RemoveRefs(func(arn string) bool {
+		return referencesKey(arn, remoteKeys)
+	})
+	existing.Merge(def)
+
+	patch := orderedObject{}
+	if err := remarshal(existing, &patch); err != nil {
+		return DownloadedFile{}, err
+	}
+
+	for _, field := range ecsConfigFields {
+		cd = cd.set(field, patch.get(field))
+	}
+
+	if hasLabels || len(labels) > 0 {
+		cd = cd.set("dockerLabels", labels)
+	}
+
+	containers[target] = cd
+
+	b, err := marshalJSON(v, "    ")
+	if err != nil {
+		return DownloadedFile{}, err
+	}
+
+	return DownloadedFile{
+		Path:   path,
+		Output: output.TypeECSContainerDef,
+		Data:   b,
+	}, nil
+}
+
+// secretSuffix is the random suffix added to Secrets Manager ARNs.
+var secretSuffix = regexp.MustCompile(`-[A-Za-z0-9]{6}$`)
+
+// referencesKey determines if the S3, Secrets Manager, or Parameter
+// Store ARN references a remote key or a key stored under it.
+func referencesKey(arn string, remoteKeys []string) bool {
+	name := ""
+
+	switch {
+	case strings.HasPrefix(arn, "arn:aws:s3:::"):
+		if parts := strings.SplitN(strings.TrimPrefix(arn, "arn:aws:s3:::"), "/", 2); len(parts) == 2 {
+			name = parts[1]
+		}
+	case strings.Contains(arn, ":secret:"):
+		// Drop the JSON key selector. (e.g. :key::)
+		name = strings.SplitN(arn[strings.Index(arn, ":secret:")+len(":secret:"):], ":", 2)[0]
+		name = secretSuffix.ReplaceAllString(name, "")
+	case strings.Contains(arn, ":parameter/"):
+		name = arn[strings.Index(arn, ":parameter/")+len(":parameter/"):]
+	}
+
+	name = strings.TrimLeft(name, "/")
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, k := range remoteKeys {
+		k = strings.TrimLeft(k, "/")
+
+		if name == k || strings.HasPrefix(name, k+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// marshalJSON encodes the value without escaping HTML characters
+// found in configuration values.
+func marshalJSON(v interface{}, indent string) ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+
+	if err := enc.Encode(v); err != nil {
+		return []byte{}, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// remarshal converts between JSON compatible types.
+func remarshal(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	if o, ok := to.(*orderedObject); ok {
+		v, err := decodeOrdered(dec)
+		if err != nil {
+			return err
+		}
+
+		*o, _ = v.(orderedObject)
+		return nil
+	}
+
+	return dec.Decode(to)
+}
+
+//-------------------------------------
+//- Ordered JSON
+//-------------------------------------
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+// orderedObject is a JSON object preserving the field order.
+type orderedObject []orderedField
+
+func (o orderedObject) get(key string) interface{} {
+	for _, f := range o {
+		if f.key == key {
+			return f.value
+		}
+	}
+
+	return nil
+}
+
+// set replaces the field value, appending new fields and removing
+// fields set to nil.
+func (o orderedObject) set(key string, value interface{}) orderedObject {
+	out := orderedObject{}
+	found := false
+
+	for _, f := range o {
+		if f.key != key {
+			out = append(out, f)
+			continue
+		}
+
+		found = true
+		if value != nil {
+			out = append(out, orderedField{key: key, value: value})
+		}
+	}
+
+	if !found && value != nil {
+		out = append(out, orderedField{key: key, value: value})
+	}
+
+	return out
+}
+
+// MarshalJSON ...
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString("{")
+	for i, f := range o {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		k, err := marshalJSON(f.key, "")
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := marshalJSON(f.value, "")
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(bytes.TrimRight(k, "\n"))
+		b.WriteString(":")
+		b.Write(bytes.TrimRight(v, "\n"))
+	}
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
+
+// decodeOrdered decodes the next JSON value keeping object fields in
+// order.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	t, err := dec.Token()
+	if err == io.EOF {
+		return nil, errors.New("unexpected end of JSON")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		o := orderedObject{}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			o = append(o, orderedField{key: k.(string), value: v})
+		}
+
+		_, err := dec.Token()
+		return o, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			a = append(a, v)
+		}
+
+		_, err := dec.Token()
+		return a, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,137 @@
+package action
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestReferencesKey(t *testing.T) {
+	keys := []string{"/app/dev/config", "app/dev/secrets", "app/dev/config.env"}
+
+	tests := []struct {
+		arn  string
+		want bool
+	}{
+		{"arn:aws:ssm:us-east-1:123456789012:parameter/app/dev/config/HOST", true},
+		{"arn:aws:ssm:us-east-1:123456789012:parameter/app/dev/configs/HOST", false},
+		{"arn:aws:secretsmanager:us-east-1:123456789012:secret:app/dev/secrets-AbCdEf:TOKEN::", true},
+		{"arn:aws:secretsmanager:us-east-1:123456789012:secret:app/dev/secrets:TOKEN::", true},
+		{"arn:aws:secretsmanager:us-east-1:123456789012:secret:app/prod/secrets-AbCdEf:TOKEN::", false},
+		{"arn:aws:s3:::bucket/app/dev/config.env", true},
+		{"arn:aws:s3:::bucket/app/dev/other.env", false},
+		{"arn:aws:s3:::bucket", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			if got := referencesKey(tt.arn, keys); got != tt.want {
+				t.Errorf("referencesKey(%q) = %v, want %v", tt.arn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchTaskDefinition(t *testing.T) {
+	dir, cleanup := testRepo(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "task-def.json")
+	writeTestFile(t, path, `{
+  "family": "api",
+  "containerDefinitions": [
+    {
+      "name": "api",
+      "image": "api:latest",
+      "environment": [
+        {"name": "OTHER", "value": "kept"},
+        {"name": "REMOVED", "value": "old"},
+        {"name": "HOST", "value": "old"}
+      ],
+      "secrets": [
+        {"name": "FOREIGN", "valueFrom": "arn:aws:secretsmanager:us-east-1:123456789012:secret:other-AbCdEf:FOREIGN::"},
+        {"name": "STALE", "valueFrom": "arn:aws:secretsmanager:us-east-1:123456789012:secret:app/dev/secrets-AbCdEf:STALE::"}
+      ],
+      "dockerLabels": {
+        "team": "platform",
+        "stash.environment:/app/dev/config": "HOST,REMOVED"
+      }
+    }
+  ]
+}`)
+
+	files := []DownloadedFile{
+		{
+			Path:       "config.env",
+			RemoteKeys: []string{"/app/dev/config"},
+			Data:       []byte(`{"environment":[{"name":"HOST","value":"new"}]}`),
+		},
+		{
+			Path:       "secrets.env",
+			RemoteKeys: []string{"app/dev/secrets"},
+			Data:       []byte(`{"secrets":[{"name":"TOKEN","valueFrom":"arn:aws:secretsmanager:us-east-1:123456789012:secret:app/dev/secrets:TOKEN::"}]}`),
+		},
+	}
+
+	patched, err := patchTaskDefinition(path, "", files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	td := struct {
+		Family     string `json:"family"`
+		Containers []struct {
+			Name        string `json:"name"`
+			Image       string `json:"image"`
+			Environment []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"environment"`
+			Secrets []struct {
+				Name string `json:"name"`
+			} `json:"secrets"`
+			DockerLabels map[string]string `json:"dockerLabels"`
+		} `json:"containerDefinitions"`
+	}{}
+
+	if err := json.Unmarshal(patched.Data, &td); err != nil {
+		t.Fatalf("%s\n%s", err, patched.Data)
+	}
+
+	if td.Family != "api" || len(td.Containers) != 1 || td.Containers[0].Image != "api:latest" {
+		t.Fatalf("unrelated fields changed\n%s", patched.Data)
+	}
+
+	c := td.Containers[0]
+
+	env := map[string]string{}
+	for _, e := range c.Environment {
+		env[e.Name] = e.Value
+	}
+
+	if len(env) != 2 || env["OTHER"] != "kept" || env["HOST"] != "new" {
+		t.Errorf("environment = %v, want OTHER=kept HOST=new", env)
+	}
+
+	secrets := map[string]bool{}
+	for _, s := range c.Secrets {
+		secrets[s.Name] = true
+	}
+
+	if len(secrets) != 2 || !secrets["FOREIGN"] || !secrets["TOKEN"] {
+		t.Errorf("secrets = %v, want FOREIGN and TOKEN", secrets)
+	}
+
+	if c.DockerLabels["team"] != "platform" {
+		t.Errorf("docker labels = %v, want team label kept", c.DockerLabels)
+	}
+
+	if got := c.DockerLabels["stash.environment:/app/dev/config"]; got != "HOST" {
+		t.Errorf("environment label = %q, want %q", got, "HOST")
+	}
+
+	if _, ok := c.DockerLabels["stash.environment:app/dev/secrets"]; ok {
+		t.Errorf("docker labels = %v, want no label for secrets", c.DockerLabels)
+	}
+}
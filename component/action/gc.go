@@ -0,0 +1,229 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/service"
+	"github.com/dabblebox/stash/component/slice"
+)
+
+// GCOpt ...
+type GCOpt struct {
+	Options
+
+	// Buckets are searched for orphaned S3 objects in addition
+	// to the buckets referenced by the catalog.
+	Buckets []string
+
+	// DryRun lists orphaned remote keys without deleting them.
+	DryRun bool
+
+	// Yes deletes every listed orphan without confirmation. Without
+	// it, orphans are only deleted after being selected.
+	Yes bool
+}
+
+// orphan is a remote object not referenced by the catalog.
+type orphan struct {
+	object service.Object
+	bucket string
+}
+
+// GC finds remote keys under the catalog context that are not
+// referenced by the catalog and deletes them after confirmation.
+// Orphans are always listed first and only deleted without a prompt
+// when Yes is set.
+func GC(opt GCOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	//-------------------------------------
+	//- Collect Tracked Keys
+	//-------------------------------------
+	tracked := map[string]bool{}
+	buckets := map[string]bool{}
+
+	for _, f := range c.Files {
+		for _, k := range f.Keys {
+			tracked[k] = true
+		}
+
+		if b, ok := f.Options[service.S3BucketOption]; ok && len(b) > 0 {
+			buckets[b] = true
+		}
+	}
+
+	for _, b := range opt.Buckets {
+		buckets[b] = true
+	}
+
+	serviceKeys := []string{}
+	for k := range service.Services {
+		if len(opt.Service) == 0 || opt.Service == k {
+			serviceKeys = append(serviceKeys, k)
+		}
+	}
+
+	if len(serviceKeys) == 0 {
+		return 0, fmt.Errorf("service %s not found ", opt.Service)
+	}
+
+	sort.Strings(serviceKeys)
+
+	deleted := 0
+
+	for _, serviceKey := range serviceKeys {
+		remote := service.Services[serviceKey]
+
+		//-------------------------------------
+		//- Find Orphans
+		//-------------------------------------
+		locations := []string{""}
+		if _, ok := remote.(*service.S3Service); ok {
+			locations = sortedKeys(buckets)
+		}
+
+		if len(locations) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(dep.Stderr, "\n%s (collecting)\n\n", bold(service.Name(serviceKey)))
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		orphans := []orphan{}
+
+		for _, bucket := range locations {
+			objects, err := remote.List(service.File{
+				Context:   c.Context,
+				RemoteKey: service.FormatObjectKey(c.Context, "", remote),
+				Options: map[string]string{
+					service.S3BucketOption: bucket,
+				},
+			}, map[string]string{})
+			if err != nil {
+				dep.Monitor.Error(err)
+				continue
+			}
+
+			for _, o := range objects {
+				if !tracked[o.Key] {
+					orphans = append(orphans, orphan{object: o, bucket: bucket})
+				}
+			}
+		}
+
+		if len(orphans) == 0 {
+			fmt.Fprintln(dep.Stderr, "no orphaned keys found")
+			continue
+		}
+
+		display := []string{}
+		for _, o := range orphans {
+			display = append(display, formatOrphan(o))
+			fmt.Fprintf(dep.Stderr, "- %s\n", display[len(display)-1])
+		}
+
+		if opt.DryRun {
+			continue
+		}
+
+		//-------------------------------------
+		//- Confirm Deletes
+		//-------------------------------------
+		selected := display
+		switch {
+		case opt.Yes:
+		case opt.Warn:
+			fmt.Fprintln(dep.Stderr)
+
+			selected = []string{}
+			prompt := &survey.MultiSelect{
+				Help:     "Selected remote keys are permanently deleted. If a local copy does not exist, configuration will be lost.",
+				Message:  "Delete",
+				Options:  display,
+				PageSize: 20,
+			}
+			if err := survey.AskOne(prompt, &selected,
+				survey.WithStdio(dep.Stdin, dep.Stdout, dep.Stderr),
+			); err != nil {
+				return deleted, err
+			}
+		default:
+			fmt.Fprintln(dep.Stderr, "\nnot deleted, confirm with --yes to delete without a prompt")
+			continue
+		}
+
+		//-------------------------------------
+		//- Delete Orphans
+		//-------------------------------------
+		for i, o := range orphans {
+			if !slice.In(display[i], selected) {
+				continue
+			}
+
+			if err := remote.Purge(service.File{
+				Context:   c.Context,
+				RemoteKey: o.object.Key,
+				Keys:      []string{o.object.Key},
+				Options: map[string]string{
+					service.S3BucketOption: o.bucket,
+				},
+			}); err != nil {
+				dep.Monitor.Error(fmt.Errorf("%s: %s", o.object.Key, err))
+				continue
+			}
+
+			deleted++
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d orphaned key(s) deleted\n\n", deleted)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return deleted, errors.New("garbage collection errors detected")
+	}
+
+	return deleted, nil
+}
+
+func formatOrphan(o orphan) string {
+	modified := "unknown"
+	if !o.object.LastModified.IsZero() {
+		modified = o.object.LastModified.Local().Format("2006-01-02 15:04")
+	}
+
+	if len(o.bucket) > 0 {
+		return fmt.Sprintf("s3://%s/%s (modified %s)", o.bucket, o.object.Key, modified)
+	}
+
+	return fmt.Sprintf("%s (modified %s)", o.object.Key, modified)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
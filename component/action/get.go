@@ -3,10 +3,10 @@ package action
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/dabblebox/stash/component/catalog"
-	"github.com/dabblebox/stash/component/file"
 	"github.com/dabblebox/stash/component/output"
 	"github.com/dabblebox/stash/component/service"
 )
@@ -16,16 +16,37 @@ type GetOpt struct {
 	Options
 
 	Output string
+
+	// TerraformDir is the module folder for Terraform outputs.
+	TerraformDir string
+
+	// Check generates Terraform without seeding user editable files
+	// so the results can be compared to the existing module.
+	Check bool
+
+	// TaskDefinition is an ECS task definition file patched with the
+	// container definition output.
+	TaskDefinition string
+
+	// Container is the container patched in the task definition.
+	Container string
 }
 
 // DownloadedFile ..
 type DownloadedFile struct {
 	Service string
 
+	// RemoteKeys are the remote key and the keys tracked for the file.
+	RemoteKeys []string
+
 	Path   string
 	Output string
 
 	Data []byte
+
+	// Remove determines if the file is no longer generated and
+	// should be deleted.
+	Remove bool
 }
 
 // Get downloads files from a remote service.
@@ -47,7 +68,7 @@ func Get(opt GetOpt, dep Dep) ([]DownloadedFile, error) {
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
@@ -59,6 +80,10 @@ func Get(opt GetOpt, dep Dep) ([]DownloadedFile, error) {
 	}
 
 	downloaded := []DownloadedFile{}
+	count := 0
+
+	tfDir := terraformDir(opt.TerraformDir, opt.Catalog)
+	tfBlocks := []tfBlock{}
 
 	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
 
@@ -87,31 +112,80 @@ func Get(opt GetOpt, dep Dep) ([]DownloadedFile, error) {
 				continue
 			}
 
-			fmt.Fprintln(dep.Stderr, formatFileDownloadText(c.Context, opt.Output, cf.Path, stashFile.RemoteKey, cf.Service))
+			fmt.Fprintln(dep.Stderr, formatFileDownloadText(opt.Output, tfDir, cf.Path, stashFile.RemoteKey, cf.Service))
 
-			result, err := remote.Download(stashFile, opt.Output)
+			format := opt.Output
+			if _, ok := output.TemplatePath(opt.Output); ok {
+				format = output.TypeOriginal
+			}
+
+			result, err := remote.Download(stashFile, format)
 			if err != nil {
 				dep.Monitor.FileError(err)
 				continue
 			}
 
-			t, err := output.GetTransformer(opt.Output, cf.Type)
+			t, err := output.GetTransformer(opt.Output, cf.Type, cf.Path)
 			if err != nil {
 				dep.Monitor.FileError(err)
 				continue
 			}
 
+			if tt, ok := t.(output.TemplateTransformer); ok {
+				tt.File = output.TemplateFile{
+					Key:       key,
+					Path:      cf.Path,
+					Type:      cf.Type,
+					Service:   cf.Service,
+					Context:   cf.ResolveContext(c.Context),
+					RemoteKey: result.RemoteKey,
+					Keys:      cf.Keys,
+					Tags:      cf.Tags,
+					Options:   cf.Options,
+					ARNs:      result.ARNs,
+				}
+
+				t = tt
+			}
+
 			d, err := t.Transform(result.Data)
 			if err != nil {
 				dep.Monitor.FileError(err)
 				continue
 			}
 
+			if isTerraform(opt.Output) {
+				if opt.Output == output.TypeTerraform {
+					legacy := legacyTerraformPath(cf.Service, cf.Path)
+					if _, err := os.Stat(legacy); err == nil {
+						dep.Monitor.FileWarn(fmt.Sprintf("%s was generated by an earlier version, remove it once %s is applied", legacy, tfDir))
+					}
+				}
+
+				tfBlocks = append(tfBlocks, tfBlock{
+					serviceKey: cf.Service,
+					Artifact: service.Artifact{
+						Name: terraformFileName(opt.Output, cf.Service),
+						ID:   key,
+						Data: d,
+					},
+					cataloged: true,
+				})
+
+				for _, a := range result.Artifacts {
+					tfBlocks = append(tfBlocks, tfBlock{serviceKey: cf.Service, Artifact: a})
+				}
+
+				count++
+				continue
+			}
+
 			downloaded = append(downloaded, DownloadedFile{
-				Service: cf.Service,
-				Path:    cf.Path,
-				Output:  opt.Output,
-				Data:    d,
+				Service:    cf.Service,
+				RemoteKeys: append([]string{stashFile.RemoteKey}, cf.Keys...),
+				Path:       cf.Path,
+				Output:     opt.Output,
+				Data:       d,
 			})
 
 			if opt.Output == output.TypeFile {
@@ -120,10 +194,71 @@ func Get(opt GetOpt, dep Dep) ([]DownloadedFile, error) {
 					continue
 				}
 			}
+
+			count++
+		}
+	}
+
+	//-------------------------------------
+	//- Merge Terraform Module
+	//-------------------------------------
+	if len(tfBlocks) > 0 {
+		files, err := terraformModule(tfDir, opt.Output, tfBlocks, c, opt.Check)
+		if err != nil {
+			return downloaded, err
+		}
+
+		fmt.Fprintf(dep.Stderr, "\n%s (module)\n\n", bold(tfDir))
+
+		for _, f := range files {
+			fmt.Fprintf(dep.Stderr, "  - [%s]\n", fileTokenColor(f.Path))
+		}
+
+		downloaded = append(downloaded, files...)
+	}
+
+	//-------------------------------------
+	//- Merge Infrastructure Templates
+	//-------------------------------------
+	if isIaC(opt.Output) && len(downloaded) > 1 {
+		merged, err := mergeIaC(downloaded)
+		if err != nil {
+			return downloaded, err
+		}
+
+		downloaded = []DownloadedFile{merged}
+	}
+
+	//-------------------------------------
+	//- Merge Container Definitions
+	//-------------------------------------
+	if opt.Output == output.TypeECSContainerDef && len(downloaded) > 0 {
+		if len(opt.TaskDefinition) > 0 {
+			patched, err := patchTaskDefinition(opt.TaskDefinition, opt.Container, downloaded)
+			if err != nil {
+				return downloaded, err
+			}
+
+			downloaded = []DownloadedFile{patched}
+		} else {
+			def, err := mergeContainerDefinitions(downloaded)
+			if err != nil {
+				return downloaded, err
+			}
+
+			d, err := def.Marshal()
+			if err != nil {
+				return downloaded, err
+			}
+
+			downloaded = []DownloadedFile{{
+				Output: opt.Output,
+				Data:   d,
+			}}
 		}
 	}
 
-	fmt.Fprintf(dep.Stderr, "\n%d file(s) downloaded\n\n", len(downloaded))
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) downloaded\n\n", count)
 
 	if len(dep.Monitor.Errors) > 0 {
 		return downloaded, errors.New("download errors detected")
@@ -132,14 +267,14 @@ func Get(opt GetOpt, dep Dep) ([]DownloadedFile, error) {
 	return downloaded, nil
 }
 
-func formatFileDownloadText(context, outputType, path, remoteKey, service string) string {
+func formatFileDownloadText(outputType, tfDir, path, remoteKey, service string) string {
 	loc := "unknown"
 
 	switch outputType {
 	case output.TypeFile:
 		loc = path
-	case output.TypeTerraform:
-		loc = fmt.Sprintf("%s/%s-%s.tf", filepath.Dir(path), service, file.DashIt(filepath.Base(path)))
+	case output.TypeTerraform, output.TypeTerraformResources:
+		loc = filepath.Join(tfDir, terraformFileName(outputType, service))
 	default:
 		loc = "output"
 	}
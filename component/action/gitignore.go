@@ -0,0 +1,141 @@
+package action
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/git"
+)
+
+// gitignoreBlockID identifies the managed block listing cataloged
+// files in .gitignore files.
+const gitignoreBlockID = "catalog"
+
+// ignoreFiles ensures git ignores the cataloged files by adding them
+// to the managed block of the nearest .gitignore. Files already
+// tracked by git are reported since ignoring them has no effect until
+// they are removed from the index. Nothing is done outside of a git
+// repository.
+func ignoreFiles(paths []string, dep Dep) {
+	if len(paths) == 0 {
+		return
+	}
+
+	root, err := git.Root()
+	if err != nil {
+		return
+	}
+
+	ignored, err := git.Ignored(paths)
+	if err != nil {
+		dep.Monitor.FileWarn(err.Error())
+		return
+	}
+
+	tracked, err := git.Tracked(paths)
+	if err != nil {
+		dep.Monitor.FileWarn(err.Error())
+		return
+	}
+
+	patterns := map[string][]string{}
+	files := map[string][]string{}
+	ignoreFiles := []string{}
+
+	for _, p := range paths {
+		if ignored[p] {
+			continue
+		}
+
+		f, err := git.NearestIgnoreFile(p, root)
+		if err != nil {
+			dep.Monitor.FileWarn(err.Error())
+			continue
+		}
+
+		pattern, err := git.IgnorePattern(f, p)
+		if err != nil {
+			dep.Monitor.FileWarn(err.Error())
+			continue
+		}
+
+		if _, ok := patterns[f]; !ok {
+			ignoreFiles = append(ignoreFiles, f)
+		}
+
+		patterns[f] = append(patterns[f], pattern)
+		files[f] = append(files[f], p)
+	}
+
+	if len(ignoreFiles) > 0 || len(tracked) > 0 {
+		fmt.Fprintf(dep.Stderr, "\n%s (ignoring)\n\n", bold(git.IgnoreFile))
+	}
+
+	for _, f := range ignoreFiles {
+		for _, p := range files[f] {
+			fmt.Fprintf(dep.Stderr, "- [%s] => [%s]\n", filePathColor(p), filePathColor(relPath(f)))
+		}
+
+		if err := addIgnorePatterns(f, patterns[f]); err != nil {
+			dep.Monitor.FileError(err)
+		}
+	}
+
+	for _, p := range paths {
+		if !tracked[filepath.Clean(p)] {
+			continue
+		}
+
+		fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(p))
+		dep.Monitor.FileWarn(fmt.Sprintf("tracked by git, run: git rm --cached %s", p))
+	}
+}
+
+// addIgnorePatterns adds the patterns to the managed block keeping
+// the existing entries.
+func addIgnorePatterns(ignoreFile string, patterns []string) error {
+	data, err := file.Read(ignoreFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	entries := map[string]bool{}
+
+	block, _ := file.Block(data, gitignoreBlockID)
+	for _, l := range strings.Split(string(block), "\n") {
+		if l = strings.TrimSpace(l); len(l) > 0 {
+			entries[l] = true
+		}
+	}
+
+	for _, p := range patterns {
+		entries[p] = true
+	}
+
+	lines := sortedKeys(entries)
+
+	return file.Write(ignoreFile, file.ReplaceBlock(data, gitignoreBlockID, []byte(strings.Join(lines, "\n"))))
+}
+
+// relPath returns the path relative to the working directory when
+// possible.
+func relPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	if d, err := filepath.EvalSymlinks(wd); err == nil {
+		wd = d
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
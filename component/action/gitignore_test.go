@@ -0,0 +1,115 @@
+package action
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dabblebox/stash/component/monitor"
+)
+
+// testRepo creates a git repository in a temporary directory and
+// makes it the working directory until the returned func is called.
+func testRepo(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "stash-action")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
+
+// testDep discards the command output.
+func testDep(t *testing.T) Dep {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := monitor.New(null, false)
+
+	return Dep{Monitor: &m, Stderr: null, Stdout: null, Stdin: null}
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIgnoreFiles(t *testing.T) {
+	_, cleanup := testRepo(t)
+	defer cleanup()
+
+	if out, err := exec.Command("git", "init", "-q").CombinedOutput(); err != nil {
+		t.Skipf("git init failed: %s %s", err, out)
+	}
+
+	writeTestFile(t, ".gitignore", "*.log\n")
+	writeTestFile(t, "api/.gitignore", "")
+	writeTestFile(t, "config/.env", "A=1")
+	writeTestFile(t, "api/.env", "B=2")
+	writeTestFile(t, "debug.log", "")
+
+	dep := testDep(t)
+
+	paths := []string{"config/.env", "api/.env", "debug.log"}
+
+	ignoreFiles(paths, dep)
+	ignoreFiles(paths, dep)
+
+	if len(dep.Monitor.Errors) > 0 {
+		t.Fatalf("unexpected errors %v", dep.Monitor.Errors)
+	}
+
+	root, _ := ioutil.ReadFile(".gitignore")
+	if !strings.HasPrefix(string(root), "*.log\n") || strings.Count(string(root), "/config/.env\n") != 1 {
+		t.Errorf("root .gitignore not updated once\n%s", root)
+	}
+
+	if strings.Contains(string(root), "debug.log") || strings.Contains(string(root), "/api/.env") {
+		t.Errorf("ignored or nested files added to the root .gitignore\n%s", root)
+	}
+
+	if api, _ := ioutil.ReadFile("api/.gitignore"); !strings.Contains(string(api), "\n/.env\n") {
+		t.Errorf("nearest .gitignore not updated\n%s", api)
+	}
+}
+
+func TestIgnoreFilesOutsideRepository(t *testing.T) {
+	_, cleanup := testRepo(t)
+	defer cleanup()
+
+	// Nested in a repository, e.g. the system temp directory, the
+	// test does not apply.
+	if out, err := exec.Command("git", "rev-parse", "--show-toplevel").CombinedOutput(); err == nil {
+		t.Skipf("temp directory is inside a repository: %s", out)
+	}
+
+	writeTestFile(t, "config/.env", "A=1")
+
+	ignoreFiles([]string{"config/.env"}, testDep(t))
+
+	if _, err := os.Stat(".gitignore"); !os.IsNotExist(err) {
+		t.Error(".gitignore written outside a repository")
+	}
+}
@@ -0,0 +1,195 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/service"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+// GrantOpt ...
+type GrantOpt struct {
+	Options
+
+	// Principal is the IAM role or user ARN.
+	Principal string
+
+	// Attach adds the policy to the principal as an inline policy.
+	Attach bool
+
+	// PolicyName names the inline policy. Defaults to the catalog
+	// context prefixed with "stash-".
+	PolicyName string
+
+	// BucketPolicy adds the principal to the bucket policies created
+	// by Stash.
+	BucketPolicy bool
+}
+
+// Grant computes the least privilege IAM policy reading the matching
+// catalog files from their cataloged keys; so, no configuration is
+// downloaded. The policy is attached to the principal and bucket
+// policies are updated when requested.
+func Grant(opt GrantOpt, dep Dep) (policy.Policy, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return policy.Policy{}, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	if len(targetFiles) == 0 {
+		return policy.Policy{}, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	am, err := service.NewAccessManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return policy.Policy{}, err
+	}
+
+	//-------------------------------------
+	//- Collect Policies
+	//-------------------------------------
+	policies := []policy.Policy{}
+	keyIDs := map[string]bool{}
+	buckets := map[string]bool{}
+	granted := 0
+
+	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s (collecting)\n\n", bold(service.Name(serviceKey)))
+
+		remote, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(cf.Path))
+
+			stashFile, err := cf.ToServiceModel(c.Context, key, remote, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			p, err := am.Policy(serviceKey, stashFile)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			policies = append(policies, p)
+			granted++
+
+			if k := service.KMSKeyID(serviceKey, cf.Options); len(k) > 0 {
+				keyIDs[k] = true
+			}
+
+			if b := cf.Options[service.S3BucketOption]; serviceKey == "s3" && len(b) > 0 {
+				buckets[b] = true
+			}
+		}
+	}
+
+	if len(dep.Monitor.Errors) > 0 {
+		return policy.Policy{}, errors.New("policy errors detected")
+	}
+
+	//-------------------------------------
+	//- Resolve Keys
+	//-------------------------------------
+	keyARNs := []string{}
+
+	for _, keyID := range sortedKeys(keyIDs) {
+		arn, err := am.KeyARN(keyID)
+		if err != nil {
+			return policy.Policy{}, err
+		}
+
+		keyARNs = append(keyARNs, arn)
+	}
+
+	if len(keyARNs) > 0 {
+		policies = append(policies, policy.New(policy.Statement{
+			Effect:   "Allow",
+			Action:   []string{"kms:Decrypt"},
+			Resource: keyARNs,
+		}))
+	}
+
+	p := policy.Merge(policies...)
+
+	//-------------------------------------
+	//- Grant Access
+	//-------------------------------------
+	if opt.Attach {
+		name := opt.PolicyName
+		if len(name) == 0 {
+			name = grantPolicyName(c.Context)
+		}
+
+		fmt.Fprintf(dep.Stderr, "\n%s (attaching %s)\n", bold(opt.Principal), name)
+
+		if err := am.Attach(opt.Principal, name, p); err != nil {
+			return p, err
+		}
+	}
+
+	if opt.BucketPolicy {
+		fmt.Fprintf(dep.Stderr, "\n%s (updating bucket policies)\n\n", bold(opt.Principal))
+
+		for _, bucket := range sortedKeys(buckets) {
+			fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(bucket))
+
+			changed, err := am.GrantBucket(bucket, opt.Principal)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			if !changed {
+				dep.Monitor.FileWarn("bucket policy unchanged")
+			}
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) granted\n\n", granted)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return p, errors.New("grant errors detected")
+	}
+
+	return p, nil
+}
+
+// grantPolicyName converts the catalog context into a valid inline
+// policy name.
+func grantPolicyName(context string) string {
+	name := regexp.MustCompile(`[^\w+=,.@-]+`).ReplaceAllString(context, "-")
+
+	return fmt.Sprintf("stash-%s", name)
+}
@@ -0,0 +1,64 @@
+package action
+
+import (
+	"github.com/dabblebox/stash/component/output"
+	"gopkg.in/yaml.v2"
+)
+
+// isIaC determines if the output is a CloudFormation template or
+// Pulumi program that must be merged into a single document.
+func isIaC(outputType string) bool {
+	return outputType == output.TypeCloudFormation || outputType == output.TypePulumi
+}
+
+// mergeIaC combines the templates generated for each file. Sections
+// like resources and outputs are merged while the first value is kept
+// for all other fields.
+func mergeIaC(files []DownloadedFile) (DownloadedFile, error) {
+	merged := yaml.MapSlice{}
+
+	for _, f := range files {
+		doc := yaml.MapSlice{}
+		if err := yaml.Unmarshal(f.Data, &doc); err != nil {
+			return DownloadedFile{}, err
+		}
+
+		merged = mergeMapSlice(merged, doc)
+	}
+
+	d, err := yaml.Marshal(merged)
+	if err != nil {
+		return DownloadedFile{}, err
+	}
+
+	return DownloadedFile{
+		Output: files[0].Output,
+		Data:   d,
+	}, nil
+}
+
+func mergeMapSlice(dst, src yaml.MapSlice) yaml.MapSlice {
+	for _, item := range src {
+		found := false
+
+		for i, existing := range dst {
+			if existing.Key != item.Key {
+				continue
+			}
+
+			found = true
+
+			a, aok := existing.Value.(yaml.MapSlice)
+			b, bok := item.Value.(yaml.MapSlice)
+			if aok && bok {
+				dst[i].Value = mergeMapSlice(a, b)
+			}
+		}
+
+		if !found {
+			dst = append(dst, item)
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,138 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// ImportOpt ...
+type ImportOpt struct {
+	Options
+
+	Context string
+
+	// Prefix limits the remote keys imported. When empty, the
+	// catalog context is used.
+	Prefix string
+
+	// Bucket is required when importing S3 objects.
+	Bucket string
+
+	// RemoteTags limits the remote objects to those with
+	// matching tags.
+	RemoteTags map[string]string
+}
+
+// Import catalogs existing remote data without modifying it.
+func Import(opt ImportOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Init(opt.Context, opt.Catalog, catalog.InitDep{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	//-------------------------------------
+	//- Validate Request
+	//-------------------------------------
+	remote, ok := service.Services[opt.Service]
+	if !ok {
+		return 0, fmt.Errorf("service %s not found ", opt.Service)
+	}
+
+	if err := remote.PreHook(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	}); err != nil {
+		return 0, fmt.Errorf("service %s failed to initialize: %s", opt.Service, err)
+	}
+
+	prefix := opt.Prefix
+	if len(prefix) == 0 {
+		prefix = service.FormatObjectKey(c.Context, "", remote)
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%s (importing)\n\n", bold(service.Name(opt.Service)))
+
+	//-------------------------------------
+	//- Discover Remote Objects
+	//-------------------------------------
+	objects, err := remote.List(service.File{
+		Context:   c.Context,
+		RemoteKey: prefix,
+		Options: map[string]string{
+			service.S3BucketOption: opt.Bucket,
+		},
+	}, opt.RemoteTags)
+	if err != nil {
+		return 0, err
+	}
+
+	files, err := remote.Import(c.Context, objects)
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].LocalPath < files[j].LocalPath })
+
+	//-------------------------------------
+	//- Catalog Files
+	//-------------------------------------
+	imported := 0
+
+	for _, sf := range files {
+		fmt.Fprintln(dep.Stderr, formatFileSyncText(c.Context, "", sf.RemoteKey, sf.LocalPath))
+
+		if _, found := c.GetFile(sf.LocalPath); found {
+			dep.Monitor.FileWarn("file already cataloged")
+			continue
+		}
+
+		tracked := false
+		for _, k := range sf.Keys {
+			if _, found := c.LookupRemoteKey(k); found {
+				tracked = true
+			}
+		}
+
+		if tracked {
+			dep.Monitor.FileWarn("remote key already cataloged")
+			continue
+		}
+
+		if _, err := c.ImportFile(remote.Key(), sf, opt.Tags); err != nil {
+			dep.Monitor.FileError(err)
+			continue
+		}
+
+		imported++
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) imported\n\n", imported)
+
+	//-------------------------------------
+	//- Save Catalog
+	//-------------------------------------
+	if imported > 0 {
+		if err := catalog.Save(opt.Catalog, c); err != nil {
+			return imported, err
+		}
+	}
+
+	if len(dep.Monitor.Errors) > 0 {
+		return imported, errors.New("import errors detected")
+	}
+
+	return imported, nil
+}
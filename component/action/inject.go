@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dabblebox/stash/component/catalog"
 	"github.com/dabblebox/stash/component/dotenv"
 	"github.com/dabblebox/stash/component/file"
 	"github.com/dabblebox/stash/component/output"
@@ -21,6 +22,11 @@ type InjectOpt struct {
 
 	Service string
 	Output  string
+
+	// Query limits the injected tokens. Each token is matched as a
+	// file with the token's remote key and the file path and type.
+	// Tokens not matching are left in place.
+	Query *catalog.Query
 }
 
 // Inject ...
@@ -78,6 +84,15 @@ func Inject(opt InjectOpt, dep Dep) ([]DownloadedFile, error) {
 				remoteFileType = file.TypeEnv
 			}
 
+			if opt.Query != nil && !opt.Query.Match(&catalog.File{
+				Path:    path,
+				Type:    strings.TrimLeft(filepath.Ext(path), "."),
+				Service: opt.Service,
+				Keys:    []string{remoteKey.String()},
+			}) {
+				continue
+			}
+
 			stashFile := service.File{
 				RemoteKey: remoteKey.String(),
 				Type:      remoteFileType,
@@ -86,7 +101,12 @@ func Inject(opt InjectOpt, dep Dep) ([]DownloadedFile, error) {
 
 			fmt.Fprintf(dep.Stderr, "  - ${%s} => (%s)\n", fileTokenColor(fileKey), fileTokenColor(remoteKey))
 
-			result, err := remote.Download(stashFile, opt.Output)
+			format := opt.Output
+			if _, ok := output.TemplatePath(opt.Output); ok {
+				format = output.TypeOriginal
+			}
+
+			result, err := remote.Download(stashFile, format)
 			if err != nil {
 				dep.Monitor.FileError(err)
 				continue
@@ -114,7 +134,7 @@ func Inject(opt InjectOpt, dep Dep) ([]DownloadedFile, error) {
 			data = token.Replace(m, data)
 		}
 
-		t, err := output.GetTransformer(opt.Output, strings.TrimLeft(filepath.Ext(path), "."))
+		t, err := output.GetTransformer(opt.Output, strings.TrimLeft(filepath.Ext(path), "."), path)
 		if err != nil {
 			dep.Monitor.FileError(err)
 			continue
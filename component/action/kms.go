@@ -0,0 +1,336 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// KMSCreateOpt ...
+type KMSCreateOpt struct {
+	// Alias names the new key.
+	Alias string
+
+	// Admins are IAM role or user ARNs allowed to manage and use
+	// the key.
+	Admins []string
+
+	// Users are IAM role or user ARNs allowed to decrypt.
+	Users []string
+}
+
+// KMSGrantOpt ...
+type KMSGrantOpt struct {
+	Options
+
+	// Principal is the IAM role or user ARN.
+	Principal string
+
+	// Revoke removes access instead of granting access.
+	Revoke bool
+}
+
+// KMSReencryptOpt ...
+type KMSReencryptOpt struct {
+	Options
+
+	// KeyID is the new key id, ARN, or alias.
+	KeyID string
+}
+
+// KMSCreate creates a KMS key for encrypting stashed data.
+func KMSCreate(opt KMSCreateOpt, dep Dep) (string, error) {
+	if len(opt.Alias) == 0 {
+		return "", errors.New("alias required")
+	}
+
+	km, err := service.NewKeyManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return km.Create(opt.Alias, opt.Admins, opt.Users)
+}
+
+// KMSGrant grants or revokes decrypt access to the keys used by the
+// matching catalog files.
+func KMSGrant(opt KMSGrantOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	keys := keyUsage(c.Filter(filter))
+	if len(keys) == 0 {
+		return 0, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	km, err := service.NewKeyManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	p, err := km.Principal(opt.Principal)
+	if err != nil {
+		return 0, err
+	}
+
+	action := "granting"
+	if opt.Revoke {
+		action = "revoking"
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%s (%s)\n\n", bold(opt.Principal), action)
+
+	updated := 0
+
+	for _, keyID := range sortedKeyIDs(keys) {
+		fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(keyID))
+
+		if service.IsAWSManagedKey(keyID) {
+			dep.Monitor.FileWarn("AWS managed key policies cannot be changed, use IAM policies instead")
+			continue
+		}
+
+		update := km.Grant
+		if opt.Revoke {
+			update = km.Revoke
+		}
+
+		changed, err := update(keyID, p)
+		if err != nil {
+			dep.Monitor.FileError(err)
+			continue
+		}
+
+		if !changed {
+			dep.Monitor.FileWarn("key policy unchanged")
+			continue
+		}
+
+		updated++
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d key policy(s) updated\n\n", updated)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return updated, errors.New("key policy errors detected")
+	}
+
+	return updated, nil
+}
+
+// KMSUsage lists the keys used by the matching catalog files.
+func KMSUsage(opt Options, dep Dep) error {
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	keys := keyUsage(c.Filter(filter))
+	if len(keys) == 0 {
+		return fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	km, err := service.NewKeyManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, keyID := range sortedKeyIDs(keys) {
+		label := keyID
+
+		if service.IsAWSManagedKey(keyID) {
+			label = fmt.Sprintf("%s (AWS managed)", keyID)
+		} else if aliases, err := km.Aliases(keyID); err != nil {
+			dep.Monitor.Error(fmt.Errorf("%s: %s", keyID, err))
+		} else if len(aliases) > 0 {
+			label = fmt.Sprintf("%s (%s)", keyID, strings.Join(aliases, ", "))
+		}
+
+		fmt.Fprintf(dep.Stdout, "\n%s\n", bold(label))
+
+		files := keys[keyID]
+		sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+		for _, f := range files {
+			fmt.Fprintf(dep.Stdout, "  - [%s] %s\n", filePathColor(f.Path), f.Service)
+		}
+	}
+
+	fmt.Fprintln(dep.Stdout)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return errors.New("key usage errors detected")
+	}
+
+	return nil
+}
+
+// KMSReencrypt stores the matching catalog files again encrypted by
+// a new key.
+func KMSReencrypt(opt KMSReencryptOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	if len(targetFiles) == 0 {
+		return 0, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	km, err := service.NewKeyManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Aliases are resolved; services create a new key when the
+	// option starts with "alias/".
+	keyID, err := km.Resolve(opt.KeyID)
+	if err != nil {
+		return 0, err
+	}
+
+	reencrypted := 0
+
+	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s (re-encrypting)\n\n", bold(service.Name(serviceKey)))
+
+		remote, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintf(dep.Stderr, "- [%s] %s => %s\n", filePathColor(cf.Path), service.KMSKeyID(serviceKey, cf.Options), keyID)
+
+			stashFile, err := cf.ToServiceModel(c.Context, key, remote, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			original, err := remote.Download(stashFile, output.TypeOriginal)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			// Copy the options; so, the catalog is only changed when
+			// the sync succeeds.
+			options := map[string]string{}
+			for k, v := range cf.Options {
+				options[k] = v
+			}
+			options[service.KMSKeyIDOption] = keyID
+
+			stashFile.Data = original.Data
+			stashFile.Options = options
+
+			result, err := remote.Sync(stashFile)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			cf.Keys = result.Keys
+			cf.Options = result.Options
+
+			c.Files[key] = cf
+
+			if err := catalog.Save(opt.Catalog, c); err != nil {
+				return reencrypted, err
+			}
+
+			if err := cf.RecordState(c.Context); err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			reencrypted++
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) re-encrypted\n\n", reencrypted)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return reencrypted, errors.New("re-encryption errors detected")
+	}
+
+	return reencrypted, nil
+}
+
+// keyUsage groups the catalog files by the key encrypting them.
+func keyUsage(files map[string]catalog.File) map[string][]catalog.File {
+	keys := map[string][]catalog.File{}
+
+	for _, f := range files {
+		k := service.KMSKeyID(f.Service, f.Options)
+		if len(k) == 0 {
+			continue
+		}
+
+		keys[k] = append(keys[k], f)
+	}
+
+	return keys
+}
+
+func sortedKeyIDs(keys map[string][]catalog.File) []string {
+	ids := []string{}
+	for k := range keys {
+		ids = append(ids, k)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
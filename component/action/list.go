@@ -25,7 +25,7 @@ func List(opt Options, dep Dep) error {
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
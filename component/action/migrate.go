@@ -0,0 +1,189 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// MigrateOpt ...
+type MigrateOpt struct {
+	Options
+
+	// To specifies the new remote service.
+	To string
+
+	// Purge deletes the original remote data after the
+	// migration has been verified.
+	Purge bool
+}
+
+// Migrate moves files from their current remote service to a new
+// remote service.
+func Migrate(opt MigrateOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	//-------------------------------------
+	//- Filter Files
+	//-------------------------------------
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	//-------------------------------------
+	//- Validate Request
+	//-------------------------------------
+	if len(targetFiles) == 0 {
+		return 0, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	to, ok := service.Services[opt.To]
+	if !ok {
+		return 0, fmt.Errorf("service %s not found ", opt.To)
+	}
+
+	if err := to.PreHook(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	}); err != nil {
+		return 0, fmt.Errorf("service %s failed to initialize: %s", opt.To, err)
+	}
+
+	migrated := 0
+
+	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s => %s (migrating)\n\n", bold(service.Name(serviceKey)), bold(service.Name(opt.To)))
+
+		if serviceKey == opt.To {
+			dep.Monitor.Error(fmt.Errorf("files already stored in %s", opt.To))
+			continue
+		}
+
+		from, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := from.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintln(dep.Stderr, formatFileSyncText(cf.ResolveContext(c.Context), key, cf.Path, service.FormatObjectKey(cf.ResolveContext(c.Context), cf.Path, to)))
+
+			if !to.Compatible([]string{cf.Type}) {
+				dep.Monitor.FileError(fmt.Errorf("%s does not support %s files", opt.To, cf.Type))
+				continue
+			}
+
+			source, err := cf.ToServiceModel(c.Context, key, from, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			// The new service tracks its own keys and options.
+			moved := cf
+			moved.Service = to.Key()
+			moved.Keys = []string{}
+			moved.Options = map[string]string{}
+
+			target, err := moved.ToServiceModel(c.Context, key, to, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			result, err := transfer(from, source, to, target)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			moved.Keys = result.Keys
+			moved.Options = result.Options
+
+			c.Files[key] = moved
+
+			if err := catalog.Save(opt.Catalog, c); err != nil {
+				return migrated, err
+			}
+
+			if err := cf.RecordState(c.Context); err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			migrated++
+
+			if opt.Purge {
+				if err := from.Purge(source); err != nil {
+					dep.Monitor.FileError(fmt.Errorf("original not purged: %s", err))
+					continue
+				}
+			}
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) migrated\n\n", migrated)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return migrated, errors.New("migration errors detected")
+	}
+
+	return migrated, nil
+}
+
+// transfer copies remote data from the source to the target and
+// verifies the copy matches the original. When the copy does not
+// match, the copy is purged leaving the original untouched.
+func transfer(from service.IService, source service.File, to service.IService, target service.File) (service.File, error) {
+
+	original, err := from.Download(source, output.TypeOriginal)
+	if err != nil {
+		return target, err
+	}
+
+	target.Data = original.Data
+
+	result, err := to.Sync(target)
+	if err != nil {
+		return result, err
+	}
+
+	check := result
+	check.Data = []byte{}
+
+	copied, err := to.Download(check, output.TypeOriginal)
+	if err != nil {
+		return result, fmt.Errorf("copy not verified: %s", err)
+	}
+
+	if !output.Equal(source.Type, original.Data, copied.Data) {
+		if err := to.Purge(result); err != nil {
+			return result, fmt.Errorf("copy does not match original and was not purged: %s", err)
+		}
+
+		return result, errors.New("copy does not match original")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,363 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// MoveOpt ...
+type MoveOpt struct {
+	Options
+
+	// From is the current local path. With a query, From is a
+	// folder and every matching file under it is moved.
+	From string
+
+	// To is the new local path. With a query, To is the new folder.
+	To string
+}
+
+// RenameContextOpt ...
+type RenameContextOpt struct {
+	Options
+
+	// Context is the new catalog context.
+	Context string
+}
+
+// relocation tracks a file copied to a new remote location.
+type relocation struct {
+	key    string
+	file   catalog.File
+	moved  catalog.File
+	source service.File
+	copy   service.File
+	remote service.IService
+}
+
+// Move changes the local path of a cataloged file relocating the
+// remote data to match the new path. With a query, every matching
+// file under the From folder is moved keeping its path below it.
+func Move(opt MoveOpt, dep Dep) error {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	//-------------------------------------
+	//- Validate Request
+	//-------------------------------------
+	moves, err := movePaths(opt, c)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{}
+	for k, to := range moves {
+		if _, found := c.GetFile(to); found {
+			return fmt.Errorf("%s already contains %s", opt.Catalog, to)
+		}
+
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	moved := 0
+
+	for _, key := range keys {
+		path := c.Files[key].Path
+
+		if err := moveFile(opt.Catalog, &c, key, moves[key], dep); err != nil {
+			if opt.Query == nil {
+				return err
+			}
+
+			dep.Monitor.Error(fmt.Errorf("%s: %s", path, err))
+			continue
+		}
+
+		moved++
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) moved\n\n", moved)
+
+	if len(dep.Monitor.Errors) > 0 {
+		return errors.New("move errors detected")
+	}
+
+	return nil
+}
+
+// movePaths maps the catalog keys being moved to their new paths.
+func movePaths(opt MoveOpt, c catalog.Catalog) (map[string]string, error) {
+	moves := map[string]string{}
+
+	if opt.Query == nil {
+		for k, f := range c.Files {
+			if f.Path == opt.From {
+				moves[k] = opt.To
+			}
+		}
+
+		if len(moves) == 0 {
+			return moves, fmt.Errorf("%s does not contain %s", opt.Catalog, opt.From)
+		}
+
+		return moves, nil
+	}
+
+	from := filepath.Clean(opt.From)
+
+	// Included files are moved from their own catalog.
+	for k, f := range c.Filter(catalog.NewGetFilter([]string{}, []string{}, "", opt.Query)) {
+		rel, err := filepath.Rel(from, filepath.Clean(f.Path))
+		if f.Included() || err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+
+		moves[k] = filepath.Join(opt.To, rel)
+	}
+
+	if len(moves) == 0 {
+		return moves, fmt.Errorf("%s does not contain matching %s under %s", opt.Catalog, opt.Query, opt.From)
+	}
+
+	return moves, nil
+}
+
+// moveFile relocates the remote data of a cataloged file, saves the
+// catalog, and moves the local file.
+func moveFile(catalogPath string, c *catalog.Catalog, key, to string, dep Dep) error {
+	cf := c.Files[key]
+
+	remote, ok := service.Services[cf.Service]
+	if !ok {
+		return fmt.Errorf("service %s not found ", cf.Service)
+	}
+
+	if err := remote.PreHook(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	}); err != nil {
+		return fmt.Errorf("service %s failed to initialize: %s", cf.Service, err)
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%s (moving)\n\n", bold(service.Name(cf.Service)))
+
+	context := cf.ResolveContext(c.Context)
+
+	fmt.Fprintln(dep.Stderr, formatFileSyncText(context, key, to, service.FormatObjectKey(context, to, remote)))
+
+	//-------------------------------------
+	//- Relocate Remote Data
+	//-------------------------------------
+	r, err := relocate(context, key, cf, remote, context, to)
+	if err != nil {
+		return err
+	}
+
+	//-------------------------------------
+	//- Update Catalog
+	//-------------------------------------
+	newKey, err := c.MoveFile(key, to)
+	if err != nil {
+		return err
+	}
+
+	c.Files[newKey] = r.moved
+
+	if err := catalog.Save(catalogPath, *c); err != nil {
+		return err
+	}
+
+	if err := r.moved.RecordState(c.Context); err != nil {
+		dep.Monitor.FileError(err)
+	}
+
+	if err := cf.RemoveState(c.Context); err != nil {
+		dep.Monitor.FileError(err)
+	}
+
+	//-------------------------------------
+	//- Move Local File
+	//-------------------------------------
+	if _, err := os.Stat(cf.Path); err == nil {
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			dep.Monitor.FileError(err)
+		} else if err := os.Rename(cf.Path, to); err != nil {
+			dep.Monitor.FileError(err)
+		}
+	}
+
+	//-------------------------------------
+	//- Delete Original Remote Data
+	//-------------------------------------
+	if err := remote.Purge(r.source); err != nil {
+		dep.Monitor.FileError(fmt.Errorf("original remote keys not deleted: %s", err))
+	}
+
+	return nil
+}
+
+// RenameContext changes the catalog context relocating the remote
+// data for every cataloged file. No changes are made unless every
+// file is relocated.
+func RenameContext(opt RenameContextOpt, dep Dep) error {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	//-------------------------------------
+	//- Validate Request
+	//-------------------------------------
+	if len(opt.Context) == 0 {
+		return errors.New("context required")
+	}
+
+	if opt.Context == c.Context {
+		return fmt.Errorf("context already %s", opt.Context)
+	}
+
+	//-------------------------------------
+	//- Relocate Remote Data
+	//-------------------------------------
+	relocated := []relocation{}
+
+	// Included files keep the context of the catalog defining them.
+	for serviceKey, catalogFiles := range catalog.GroupByService(c.Own()) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s (renaming)\n\n", bold(service.Name(serviceKey)))
+
+		remote, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintln(dep.Stderr, formatFileSyncText(opt.Context, key, cf.Path, service.FormatObjectKey(opt.Context, cf.Path, remote)))
+
+			r, err := relocate(c.Context, key, cf, remote, opt.Context, cf.Path)
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			relocated = append(relocated, r)
+		}
+	}
+
+	// Remove the copies leaving the original context intact.
+	if len(dep.Monitor.Errors) > 0 {
+		for _, r := range relocated {
+			if err := r.remote.Purge(r.copy); err != nil {
+				dep.Monitor.Error(fmt.Errorf("%s copy not removed: %s", r.file.Path, err))
+			}
+		}
+
+		return errors.New("context rename errors detected")
+	}
+
+	//-------------------------------------
+	//- Update Catalog
+	//-------------------------------------
+	oldContext := c.Context
+
+	c.Context = opt.Context
+
+	for _, r := range relocated {
+		c.Files[r.key] = r.moved
+	}
+
+	if err := catalog.Save(opt.Catalog, c); err != nil {
+		return err
+	}
+
+	//-------------------------------------
+	//- Delete Original Remote Data
+	//-------------------------------------
+	for _, r := range relocated {
+		if err := r.moved.RecordState(opt.Context); err != nil {
+			dep.Monitor.Error(err)
+		}
+
+		if err := r.file.RemoveState(oldContext); err != nil {
+			dep.Monitor.Error(err)
+		}
+
+		if err := r.remote.Purge(r.source); err != nil {
+			dep.Monitor.Error(fmt.Errorf("%s original remote keys not deleted: %s", r.file.Path, err))
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d file(s) renamed\n\n", len(relocated))
+
+	if len(dep.Monitor.Errors) > 0 {
+		return errors.New("context rename errors detected")
+	}
+
+	return nil
+}
+
+// relocate copies the remote data for a cataloged file to the
+// remote keys derived from a new context and path.
+func relocate(context, key string, cf catalog.File, remote service.IService, newContext, newPath string) (relocation, error) {
+
+	source, err := cf.ToServiceModel(context, key, remote, []byte{})
+	if err != nil {
+		return relocation{}, err
+	}
+
+	// New remote keys are derived from the new context and path.
+	moved := cf
+	moved.Path = newPath
+	moved.Keys = []string{}
+
+	target, err := moved.ToServiceModel(newContext, key, remote, []byte{})
+	if err != nil {
+		return relocation{}, err
+	}
+
+	result, err := transfer(remote, source, remote, target)
+	if err != nil {
+		return relocation{}, err
+	}
+
+	moved.Keys = result.Keys
+	moved.Options = result.Options
+
+	return relocation{
+		key:    key,
+		file:   cf,
+		moved:  moved,
+		source: source,
+		copy:   result,
+		remote: remote,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package action
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dabblebox/stash/component/catalog"
+)
+
+func TestMovePaths(t *testing.T) {
+	cat := catalog.Catalog{Files: map[string]catalog.File{
+		"dev-api":    {Path: "config/dev/api.env", Tags: []string{"api"}},
+		"dev-web":    {Path: "config/dev/web/.env", Tags: []string{"api", "web"}},
+		"dev-worker": {Path: "config/dev/worker.env", Tags: []string{"worker"}},
+		"devops":     {Path: "config/devops/api.env", Tags: []string{"api"}},
+		"shared":     {Path: "config/dev/shared.env", Tags: []string{"api"}, Source: "../shared/stash.yml"},
+	}}
+
+	query := func(expr string) *catalog.Query {
+		q, err := catalog.ParseQuery(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return q
+	}
+
+	cases := []struct {
+		name     string
+		opt      MoveOpt
+		expected map[string]string
+		err      bool
+	}{
+		{
+			name:     "file",
+			opt:      MoveOpt{From: "config/dev/api.env", To: "config/development/api.env"},
+			expected: map[string]string{"dev-api": "config/development/api.env"},
+		},
+		{
+			name: "missing file",
+			opt:  MoveOpt{From: "config/dev", To: "config/development"},
+			err:  true,
+		},
+		{
+			name: "query",
+			opt:  MoveOpt{Options: Options{Query: query("tag:api")}, From: "config/dev/", To: "config/development"},
+			expected: map[string]string{
+				"dev-api": "config/development/api.env",
+				"dev-web": "config/development/web/.env",
+			},
+		},
+		{
+			name: "query without matches",
+			opt:  MoveOpt{Options: Options{Query: query("tag:missing")}, From: "config/dev", To: "config/development"},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := movePaths(c.opt, cat)
+			if c.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
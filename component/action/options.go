@@ -3,6 +3,7 @@ package action
 import (
 	"os"
 
+	"github.com/dabblebox/stash/component/catalog"
 	"github.com/dabblebox/stash/component/monitor"
 )
 
@@ -15,6 +16,9 @@ type Options struct {
 
 	Service string
 
+	// Query is an optional filter expression.
+	Query *catalog.Query
+
 	Warn bool
 }
 
@@ -23,7 +23,7 @@ func Purge(opt Options, dep Dep) (int, int, error) {
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
@@ -63,6 +63,11 @@ func Purge(opt Options, dep Dep) (int, int, error) {
 
 			fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(sf.RemoteKey))
 
+			if cf.Included() {
+				dep.Monitor.FileWarn(fmt.Sprintf("included from %s, purge it from that catalog", cf.Source))
+				continue
+			}
+
 			if opt.Warn && len(cf.Keys) > 0 {
 				filePathConfirm := ""
 				prompt := &survey.Input{
@@ -0,0 +1,166 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/git"
+	"github.com/dabblebox/stash/component/scan"
+	"github.com/dabblebox/stash/component/search"
+)
+
+// scanExcludes are skipped by default. Dependencies and lock files
+// are full of checksums that look like secrets.
+var scanExcludes = []string{
+	`(^|/)(node_modules|vendor)/`,
+	`(^|/)(go\.sum|package-lock\.json|[^/]+\.lock)$`,
+}
+
+// ScanOpt ...
+type ScanOpt struct {
+	Catalog string
+
+	// Files are scanned instead of walking the working directory.
+	// (e.g. staged files passed by a pre-commit hook)
+	Files []string
+
+	// Exclude are regular expressions matching paths not scanned.
+	Exclude []string
+}
+
+// Scan flags files containing likely secrets that are not cataloged
+// and cataloged files not ignored by git. Files ignored by git cannot
+// be committed and are not scanned.
+func Scan(opt ScanOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil && err != os.ErrNotExist {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	cataloged := map[string]bool{}
+	for _, f := range c.Files {
+		cataloged[filepath.Clean(f.Path)] = true
+	}
+
+	//-------------------------------------
+	//- Collect Files
+	//-------------------------------------
+	excludes := []*regexp.Regexp{}
+	for _, e := range append(scanExcludes, opt.Exclude...) {
+		re, err := regexp.Compile(e)
+		if err != nil {
+			return 0, err
+		}
+
+		excludes = append(excludes, re)
+	}
+
+	paths := opt.Files
+	if len(paths) == 0 {
+		if paths, err = search.By(".*"); err != nil {
+			return 0, err
+		}
+	}
+
+	candidates := []string{}
+	for _, p := range paths {
+		p = filepath.Clean(p)
+
+		if p == filepath.Clean(opt.Catalog) || cataloged[p] || excluded(p, excludes) {
+			continue
+		}
+
+		candidates = append(candidates, p)
+	}
+
+	local := []string{}
+	for p := range cataloged {
+		if _, err := os.Stat(p); err == nil {
+			local = append(local, p)
+		}
+	}
+
+	sort.Strings(candidates)
+	sort.Strings(local)
+
+	ignored, err := git.Ignored(append(candidates, local...))
+	if err != nil {
+		// Without git, every file is scanned and cataloged files
+		// cannot be checked.
+		dep.Monitor.FileWarn(fmt.Sprintf("%s; scanning all files", err))
+		local = []string{}
+	}
+
+	issues := 0
+
+	//-------------------------------------
+	//- Scan Uncataloged Files
+	//-------------------------------------
+	fmt.Fprintf(dep.Stderr, "\n%s (scanning uncataloged files)\n\n", bold("secrets"))
+
+	for _, p := range candidates {
+		if ignored[p] {
+			continue
+		}
+
+		data, err := file.Read(p)
+		if err != nil || scan.Binary(data) {
+			continue
+		}
+
+		findings := scan.Data(data)
+		if len(findings) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(p))
+
+		for _, f := range findings {
+			dep.Monitor.FileError(fmt.Errorf("line %d: %s", f.Line, f.Rule))
+			issues++
+		}
+	}
+
+	//-------------------------------------
+	//- Check Cataloged Files
+	//-------------------------------------
+	fmt.Fprintf(dep.Stderr, "\n%s (checking cataloged files)\n\n", bold("gitignore"))
+
+	for _, p := range local {
+		if ignored[p] {
+			continue
+		}
+
+		fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(p))
+		dep.Monitor.FileError(errors.New("cataloged file is not ignored by git"))
+		issues++
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d issue(s) found\n\n", issues)
+
+	if issues > 0 {
+		return issues, errors.New("likely secrets detected")
+	}
+
+	return 0, nil
+}
+
+func excluded(path string, excludes []*regexp.Regexp) bool {
+	for _, re := range excludes {
+		if re.MatchString(filepath.ToSlash(path)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -25,6 +27,10 @@ type SyncOpt struct {
 	Options
 
 	Context string
+
+	// Gitignore adds new files to the managed block of the nearest
+	// .gitignore.
+	Gitignore bool
 }
 
 func (so *SyncOpt) addFiles(paths []string) {
@@ -60,14 +66,14 @@ func Sync(opt SyncOpt, dep Dep) error {
 	}
 
 	//-------------------------------------
-	//- Search By Regex (when not found)
+	//- Search By Regex or Glob (when not found)
 	//-------------------------------------
 	userSearched := []string{}
 	userSpecified := []string{}
 
 	for _, fp := range opt.Files {
 		if info, err := os.Stat(fp); err != nil || info.IsDir() {
-			results, err := search.By(fp)
+			results, err := searchFiles(fp)
 			if err != nil {
 				return err
 			}
@@ -110,6 +116,8 @@ backslashes. Example: ^.*\\.env$`,
 	//-------------------------------------
 	//- Catalog New Files
 	//-------------------------------------
+	cataloged := []string{}
+
 	for _, fp := range opt.Files {
 		if _, found := c.GetFile(fp); !found {
 
@@ -141,13 +149,22 @@ backslashes. Example: ^.*\\.env$`,
 			if err := c.AddFile("", fp, remote.Key(), opt.Tags); err != nil {
 				return err
 			}
+
+			cataloged = append(cataloged, fp)
 		}
 	}
 
+	//-------------------------------------
+	//- Ignore New Files
+	//-------------------------------------
+	if opt.Gitignore {
+		ignoreFiles(cataloged, dep)
+	}
+
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewPushFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewPushFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
@@ -190,7 +207,14 @@ backslashes. Example: ^.*\\.env$`,
 		}
 
 		for key, cf := range catalogFiles {
-			fmt.Fprintln(dep.Stderr, formatFileSyncText(c.Context, key, cf.Path, service.FormatObjectKey(c.Context, cf.Path, remote)))
+			fmt.Fprintln(dep.Stderr, formatFileSyncText(cf.ResolveContext(c.Context), key, cf.Path, service.FormatObjectKey(cf.ResolveContext(c.Context), cf.Path, remote)))
+
+			// The local path is relative to this catalog, not the
+			// catalog defining the file and its remote key.
+			if cf.Included() {
+				dep.Monitor.FileWarn(fmt.Sprintf("included from %s, sync it from that catalog", cf.Source))
+				continue
+			}
 
 			data, err := file.Read(cf.Path)
 			if err != nil {
@@ -251,3 +275,23 @@ func formatFileSyncText(context, key, path, remote string) string {
 
 	return fmt.Sprintf("- [%s] => [%s]", filePathColor(key), filePathColor(remote))
 }
+
+// searchFiles finds the files in the working directory matching a
+// regular expression. Regular expressions come first; so, existing
+// expressions (e.g. config.*) keep matching the same files. Invalid
+// or unmatched expressions are tried as globs.
+func searchFiles(expr string) ([]string, error) {
+	results := []string{}
+
+	if _, err := regexp.Compile(expr); err == nil {
+		if results, err = search.By(expr); err != nil {
+			return results, err
+		}
+	}
+
+	if len(results) == 0 && catalog.IsGlob(expr) {
+		return search.By(catalog.GlobRegexp(filepath.Clean(expr)).String())
+	}
+
+	return results, nil
+}
@@ -0,0 +1,103 @@
+package action
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSyncIncludedFiles(t *testing.T) {
+	_, cleanup := testRepo(t)
+	defer cleanup()
+
+	writeTestFile(t, "shared/stash.yml", `
+version: 3
+context: shared
+files:
+  observability-env:
+    path: observability.env
+    type: env
+    service: secrets-manager
+    keys:
+    - shared/observability.env
+`)
+	writeTestFile(t, "api/stash.yml", `
+version: 3
+context: api
+include:
+- ../shared/stash.yml
+`)
+
+	// The local copy downloaded next to the including catalog.
+	writeTestFile(t, "api/observability.env", "A=1")
+
+	if err := os.Chdir("api"); err != nil {
+		t.Fatal(err)
+	}
+
+	dep := testDep(t)
+
+	// Included files are skipped before the service is called; so,
+	// the sync succeeds without credentials.
+	if err := Sync(SyncOpt{Options: Options{Catalog: "stash.yml"}}, dep); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dep.Monitor.Errors) > 0 {
+		t.Errorf("unexpected errors %v", dep.Monitor.Errors)
+	}
+
+	if err := Sync(SyncOpt{Options: Options{Catalog: "stash.yml", Files: []string{"observability.env"}}}, dep); err != nil {
+		t.Fatal(err)
+	}
+
+	shared, err := ioutil.ReadFile("../shared/stash.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(shared), "- shared/observability.env") {
+		t.Errorf("included catalog changed\n%s", shared)
+	}
+}
+
+func TestSearchFiles(t *testing.T) {
+	_, cleanup := testRepo(t)
+	defer cleanup()
+
+	for _, p := range []string{"config.yml", "config.json", "configs/app.env", "api/dev.env", "api/config/prod.env"} {
+		writeTestFile(t, p, "A=1")
+	}
+
+	cases := []struct {
+		expr     string
+		expected []string
+	}{
+		// Valid regular expressions keep their meaning.
+		{expr: "config.*", expected: []string{"api/config/prod.env", "config.json", "config.yml", "configs/app.env"}},
+		{expr: `^api/.*\.env$`, expected: []string{"api/config/prod.env", "api/dev.env"}},
+		// Invalid regular expressions are globs.
+		{expr: "*.env", expected: []string{}},
+		{expr: "**/*.env", expected: []string{"api/config/prod.env", "api/dev.env", "configs/app.env"}},
+		// Unmatched regular expressions are globs.
+		{expr: "api/*.env", expected: []string{"api/dev.env"}},
+		{expr: "missing", expected: []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			actual, err := searchFiles(c.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(actual)
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
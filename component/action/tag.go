@@ -32,7 +32,7 @@ func Tag(opt TagOpt, dep Dep) error {
 	//-------------------------------------
 	//- Filter Files
 	//-------------------------------------
-	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service)
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
 
 	targetFiles := c.Filter(filter)
 
@@ -0,0 +1,192 @@
+package action
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+)
+
+// TerraformDirName is the default module folder created next to the
+// catalog.
+const TerraformDirName = "terraform"
+
+// tfBlock is a managed block within a Terraform module file.
+type tfBlock struct {
+	serviceKey string
+
+	service.Artifact
+
+	// cataloged blocks are owned by a catalog file and removed
+	// when the catalog file no longer uses the service.
+	cataloged bool
+}
+
+// isTerraform determines if the output is written to the Terraform
+// module.
+func isTerraform(outputType string) bool {
+	return outputType == output.TypeTerraform || outputType == output.TypeTerraformResources
+}
+
+// terraformDir returns the module folder for the catalog context.
+func terraformDir(dir, catalogPath string) string {
+	if len(dir) > 0 {
+		return dir
+	}
+
+	return filepath.Join(filepath.Dir(catalogPath), TerraformDirName)
+}
+
+// terraformFileName returns the module file holding the generated
+// Terraform for each cataloged file in a service.
+func terraformFileName(outputType, serviceKey string) string {
+	if outputType == output.TypeTerraformResources {
+		return fmt.Sprintf("%s-resources.tf", serviceKey)
+	}
+
+	return fmt.Sprintf("%s.tf", serviceKey)
+}
+
+// legacyTerraformPath returns the file written next to each cataloged
+// file before Terraform was generated into a module.
+func legacyTerraformPath(serviceKey, path string) string {
+	return filepath.Join(filepath.Dir(path), fmt.Sprintf("%s-%s.tf", serviceKey, file.DashIt(filepath.Base(path))))
+}
+
+// terraformModule merges the generated blocks into the existing module
+// files. Content outside managed blocks is left untouched. Files that
+// are user editable are only seeded when missing and never when
+// checking for changes. Only files whose data changed are returned.
+// Service files not generated by the blocks are pruned; so, blocks of
+// services no longer cataloged are removed, along with files left
+// empty.
+func terraformModule(dir, outputType string, blocks []tfBlock, c catalog.Catalog, check bool) ([]DownloadedFile, error) {
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].Name != blocks[j].Name {
+			return blocks[i].Name < blocks[j].Name
+		}
+
+		if blocks[i].ID != blocks[j].ID {
+			return blocks[i].ID < blocks[j].ID
+		}
+
+		return blocks[i].serviceKey < blocks[j].serviceKey
+	})
+
+	files := []DownloadedFile{}
+
+	for i := 0; i < len(blocks); {
+		name := blocks[i].Name
+
+		path := filepath.Join(dir, name)
+
+		existing, err := file.Read(path)
+		if err != nil && !os.IsNotExist(err) {
+			return files, err
+		}
+		exists := err == nil
+
+		data := existing
+
+		for ; i < len(blocks) && blocks[i].Name == name; i++ {
+			b := blocks[i]
+
+			if b.Seed != nil {
+				if exists || check {
+					continue
+				}
+
+				d, err := b.Seed()
+				if err != nil {
+					return files, err
+				}
+
+				// Seeded files belong to the user; so, no managed block.
+				data = append(data, d...)
+				continue
+			}
+
+			data = file.ReplaceBlock(data, b.ID, b.Data)
+
+			if b.cataloged {
+				data = pruneBlocks(data, b.serviceKey, c)
+			}
+		}
+
+		if bytes.Equal(data, existing) && (exists || len(data) == 0) {
+			continue
+		}
+
+		files = append(files, DownloadedFile{
+			Service: blocks[i-1].serviceKey,
+			Path:    path,
+			Output:  outputType,
+			Data:    data,
+		})
+	}
+
+	//-------------------------------------
+	//- Prune Service Files
+	//-------------------------------------
+	generated := map[string]bool{}
+	for _, b := range blocks {
+		generated[b.Name] = true
+	}
+
+	serviceKeys := []string{}
+	for k := range service.Services {
+		serviceKeys = append(serviceKeys, k)
+	}
+	sort.Strings(serviceKeys)
+
+	for _, serviceKey := range serviceKeys {
+		name := terraformFileName(outputType, serviceKey)
+		if generated[name] {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		existing, err := file.Read(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return files, err
+		}
+
+		data := pruneBlocks(existing, serviceKey, c)
+		if bytes.Equal(data, existing) {
+			continue
+		}
+
+		files = append(files, DownloadedFile{
+			Service: serviceKey,
+			Path:    path,
+			Output:  outputType,
+			Data:    data,
+			Remove:  len(bytes.TrimSpace(data)) == 0,
+		})
+	}
+
+	return files, nil
+}
+
+// pruneBlocks removes blocks for files no longer cataloged in the
+// service.
+func pruneBlocks(data []byte, serviceKey string, c catalog.Catalog) []byte {
+	for _, id := range file.BlockIDs(data) {
+		if cf, ok := c.Files[id]; !ok || cf.Service != serviceKey {
+			data = file.RemoveBlock(data, id)
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,81 @@
+package action
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+)
+
+func TestTerraformModule(t *testing.T) {
+	dir, cleanup := testRepo(t)
+	defer cleanup()
+
+	tfDir := filepath.Join(dir, TerraformDirName)
+
+	c := catalog.Catalog{Files: map[string]catalog.File{
+		"api__env": {Path: "api/.env", Service: "s3"},
+	}}
+
+	blocks := func() []tfBlock {
+		return []tfBlock{{
+			serviceKey: "s3",
+			Artifact: service.Artifact{
+				Name: terraformFileName(output.TypeTerraform, "s3"),
+				ID:   "api__env",
+				Data: []byte("# s3 policy\n"),
+			},
+			cataloged: true,
+		}}
+	}
+
+	files, err := terraformModule(tfDir, output.TypeTerraform, blocks(), c, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].Path != filepath.Join(tfDir, "s3.tf") {
+		t.Fatalf("expected s3.tf to be generated, got %v", files)
+	}
+
+	writeTestFile(t, files[0].Path, string(files[0].Data))
+
+	// Unchanged files are not returned; so, --check passes.
+	files, err = terraformModule(tfDir, output.TypeTerraform, blocks(), c, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Errorf("expected no changed files, got %d", len(files))
+	}
+
+	// A service without cataloged files leaves an orphaned file.
+	orphan := filepath.Join(tfDir, "parameter-store.tf")
+	writeTestFile(t, orphan, string(file.ReplaceBlock([]byte{}, "web__env", []byte("# parameter policy\n"))))
+
+	files, err = terraformModule(tfDir, output.TypeTerraform, blocks(), c, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].Path != orphan || !files[0].Remove {
+		t.Fatalf("expected %s to be removed, got %v", orphan, files)
+	}
+
+	// Content outside managed blocks is kept.
+	writeTestFile(t, orphan, "# user\n\n"+string(file.ReplaceBlock([]byte{}, "web__env", []byte("# parameter policy\n"))))
+
+	files, err = terraformModule(tfDir, output.TypeTerraform, blocks(), c, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].Remove || strings.TrimSpace(string(files[0].Data)) != "# user" {
+		t.Fatalf("expected the managed block to be pruned, got %v", files)
+	}
+}
@@ -0,0 +1,76 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+)
+
+// Validate checks the catalog for schema errors and problems that
+// would otherwise surface when files are synced or restored. The
+// number of errors is returned; warnings are only reported.
+func Validate(opt Options, dep Dep) (int, error) {
+	data, err := file.Read(opt.Catalog)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("%s: %s", opt.Catalog, os.ErrNotExist)
+		}
+
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%s (validating)\n\n", bold(opt.Catalog))
+
+	problems := catalog.ValidateSchema(data)
+
+	decodable := true
+	for _, p := range problems {
+		decodable = decodable && p.Warning
+	}
+
+	// The catalog is only checked further when it can be decoded.
+	if decodable {
+		c, err := catalog.Read(opt.Catalog)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+		}
+
+		problems = append(problems, c.Validate()...)
+	}
+
+	errs, warnings := 0, 0
+	key := "-"
+
+	for _, p := range problems {
+		if p.Key != key {
+			key = p.Key
+
+			label := p.Key
+			if len(label) == 0 {
+				label = opt.Catalog
+			}
+
+			fmt.Fprintf(dep.Stderr, "- [%s]\n", filePathColor(label))
+		}
+
+		if p.Warning {
+			dep.Monitor.FileWarn(p.Err.Error())
+			warnings++
+			continue
+		}
+
+		dep.Monitor.FileError(p.Err)
+		errs++
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d error(s), %d warning(s)\n\n", errs, warnings)
+
+	if errs > 0 {
+		return errs, errors.New("catalog errors detected")
+	}
+
+	return 0, nil
+}
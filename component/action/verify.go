@@ -0,0 +1,203 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service"
+	"github.com/dabblebox/stash/component/slice"
+)
+
+// VerifyOpt ...
+type VerifyOpt struct {
+	Options
+
+	// Local compares the remote data to the local files.
+	Local bool
+}
+
+// Verify checks each catalog file against its service. Every key must
+// exist remotely, be encrypted with the configured KMS key, and be
+// decryptable. Remote keys not in the catalog are reported as
+// warnings. The number of files verified without errors is returned.
+func Verify(opt VerifyOpt, dep Dep) (int, error) {
+
+	//-------------------------------------
+	//- Init Catalog
+	//-------------------------------------
+	c, err := catalog.Read(opt.Catalog)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", opt.Catalog, err)
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	targetFiles := c.Filter(filter)
+
+	if len(targetFiles) == 0 {
+		return 0, fmt.Errorf("%s does not contain matching %s ", opt.Catalog, filter.Format(" or "))
+	}
+
+	km, err := service.NewKeyManager(service.IO{
+		Stdin:  dep.Stdin,
+		Stdout: dep.Stdout,
+		Stderr: dep.Stderr,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	keyIDs := map[string]string{}
+	keyID := func(k string) (string, error) {
+		if id, ok := keyIDs[k]; ok {
+			return id, nil
+		}
+
+		id, err := km.KeyID(k)
+		if err != nil {
+			return "", err
+		}
+
+		keyIDs[k] = id
+
+		return id, nil
+	}
+
+	verified := 0
+
+	for serviceKey, catalogFiles := range catalog.GroupByService(targetFiles) {
+
+		fmt.Fprintf(dep.Stderr, "\n%s (verifying)\n\n", bold(service.Name(serviceKey)))
+
+		remote, ok := service.Services[serviceKey]
+		if !ok {
+			dep.Monitor.Error(fmt.Errorf("service %s not found ", serviceKey))
+			continue
+		}
+
+		if err := remote.PreHook(service.IO{
+			Stdin:  dep.Stdin,
+			Stdout: dep.Stdout,
+			Stderr: dep.Stderr,
+		}); err != nil {
+			dep.Monitor.Error(fmt.Errorf("service %s failed to initialize: %s", serviceKey, err))
+			continue
+		}
+
+		for key, cf := range catalogFiles {
+			fmt.Fprintln(dep.Stderr, formatFileSyncText(cf.ResolveContext(c.Context), key, cf.Path, service.FormatObjectKey(cf.ResolveContext(c.Context), cf.Path, remote)))
+
+			errs := len(dep.Monitor.Errors)
+
+			stashFile, err := cf.ToServiceModel(c.Context, key, remote, []byte{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			if len(cf.Keys) == 0 {
+				dep.Monitor.FileError(errors.New("keys are empty, the file has not been synced"))
+				continue
+			}
+
+			//-------------------------------------
+			//- Missing and Extra Keys
+			//-------------------------------------
+			objects, err := remote.List(stashFile, map[string]string{})
+			if err != nil {
+				dep.Monitor.FileError(err)
+				continue
+			}
+
+			// Imported keys may be stored outside the context.
+			listed := map[string]service.Object{}
+			for _, o := range objects {
+				if slice.In(o.Key, cf.Keys) || o.Key == stashFile.RemoteKey || strings.HasPrefix(o.Key, stashFile.RemoteKey+"/") {
+					listed[o.Key] = o
+				}
+			}
+
+			expectedKeyID, err := keyID(service.KMSKeyID(serviceKey, cf.Options))
+			if err != nil {
+				dep.Monitor.FileError(err)
+			}
+
+			for _, k := range cf.Keys {
+				o, ok := listed[k]
+				if !ok {
+					dep.Monitor.FileError(fmt.Errorf("missing key %s", k))
+					continue
+				}
+
+				delete(listed, k)
+
+				//-------------------------------------
+				//- KMS Keys
+				//-------------------------------------
+				objectKey := service.ObjectKMSKeyID(serviceKey, o)
+				if len(objectKey) == 0 || len(expectedKeyID) == 0 {
+					continue
+				}
+
+				id, err := keyID(objectKey)
+				if err != nil {
+					dep.Monitor.FileError(err)
+					continue
+				}
+
+				if id != expectedKeyID {
+					dep.Monitor.FileError(fmt.Errorf("key %s is encrypted with %s instead of %s", k, objectKey, service.KMSKeyID(serviceKey, cf.Options)))
+				}
+			}
+
+			extra := []string{}
+			for k := range listed {
+				extra = append(extra, k)
+			}
+			sort.Strings(extra)
+
+			for _, k := range extra {
+				dep.Monitor.FileWarn(fmt.Sprintf("extra key %s is not cataloged", k))
+			}
+
+			//-------------------------------------
+			//- Decrypt
+			//-------------------------------------
+			df, err := remote.Download(stashFile, output.TypeOriginal)
+			if err != nil {
+				dep.Monitor.FileError(fmt.Errorf("undecryptable: %s", err))
+				continue
+			}
+
+			if opt.Local {
+				local, err := file.Read(cf.Path)
+				switch {
+				case os.IsNotExist(err):
+					dep.Monitor.FileWarn("local file not found")
+				case err != nil:
+					dep.Monitor.FileError(err)
+				case !output.Equal(cf.Type, local, df.Data):
+					dep.Monitor.FileError(errors.New("remote data differs from the local file"))
+				}
+			}
+
+			if len(dep.Monitor.Errors) == errs {
+				verified++
+			}
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d of %d file(s) verified\n\n", verified, len(targetFiles))
+
+	if len(dep.Monitor.Errors) > 0 {
+		return verified, errors.New("verification errors detected")
+	}
+
+	return verified, nil
+}
@@ -0,0 +1,93 @@
+package action
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/dabblebox/stash/component/catalog"
+	"github.com/dabblebox/stash/component/monitor"
+	"github.com/dabblebox/stash/component/search"
+)
+
+// Workspace runs the command for every catalog under the working
+// directory. (e.g. a monorepo) Catalog paths are relative to the
+// catalog folder; so, the command runs in each catalog's folder.
+// Catalogs without files matching the filters are skipped. The
+// number of catalogs run is returned.
+func Workspace(opt Options, dep Dep, run func(opt Options, dep Dep) error) (int, error) {
+	name := filepath.Base(opt.Catalog)
+
+	catalogs, err := search.By(fmt.Sprintf(`(^|/)%s$`, regexp.QuoteMeta(name)))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(catalogs)
+
+	if len(catalogs) == 0 {
+		return 0, fmt.Errorf("%s not found in workspace", name)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return 0, err
+	}
+
+	filter := catalog.NewGetFilter(opt.Files, opt.Tags, opt.Service, opt.Query)
+
+	ran, failed, skipped := 0, 0, 0
+
+	for _, p := range catalogs {
+		fmt.Fprintf(dep.Stderr, "\n%s\n", bold(fmt.Sprintf("### %s", p)))
+
+		c, err := catalog.Read(p)
+		if err != nil {
+			dep.Monitor.Error(fmt.Errorf("%s: %s", p, err))
+			failed++
+			continue
+		}
+
+		if len(c.Filter(filter)) == 0 {
+			fmt.Fprintf(dep.Stderr, "\nno matching %s\n", filter.Format(" or "))
+			skipped++
+			continue
+		}
+
+		if err := os.Chdir(filepath.Dir(p)); err != nil {
+			return ran, err
+		}
+
+		// Each catalog reports its own errors.
+		m := monitor.New(dep.Stderr, dep.Monitor.Logs)
+
+		co := opt
+		co.Catalog = name
+
+		cd := dep
+		cd.Monitor = &m
+
+		err = run(co, cd)
+
+		if cerr := os.Chdir(wd); cerr != nil {
+			return ran, cerr
+		}
+
+		ran++
+
+		if err != nil {
+			dep.Monitor.Error(fmt.Errorf("%s: %s", p, err))
+			failed++
+		}
+	}
+
+	fmt.Fprintf(dep.Stderr, "\n%d catalog(s) succeeded, %d failed, %d skipped\n\n", len(catalogs)-failed-skipped, failed, skipped)
+
+	if failed > 0 {
+		return ran, errors.New("workspace errors detected")
+	}
+
+	return ran, nil
+}
@@ -0,0 +1,100 @@
+package action
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWorkspace(t *testing.T) {
+	_, cleanup := testRepo(t)
+	defer cleanup()
+
+	catalogFile := func(context, tag string) string {
+		return `
+version: 3
+context: ` + context + `
+files:
+  env:
+    path: .env
+    type: env
+    service: secrets-manager
+    tags:
+    - ` + tag + `
+`
+	}
+
+	writeTestFile(t, "api/stash.yml", catalogFile("api", "dev"))
+	writeTestFile(t, "services/web/stash.yml", catalogFile("web", "dev"))
+	writeTestFile(t, "worker/stash.yml", catalogFile("worker", "prod"))
+	writeTestFile(t, "broken/stash.yml", "files: [")
+
+	root, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirs := []string{}
+
+	dep := testDep(t)
+
+	ran, err := Workspace(Options{Catalog: "stash.yml", Tags: []string{"dev"}}, dep, func(o Options, d Dep) error {
+		if o.Catalog != "stash.yml" {
+			t.Errorf("expected the catalog name, got %s", o.Catalog)
+		}
+
+		if d.Monitor == dep.Monitor {
+			t.Error("expected a monitor for each catalog")
+		}
+
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, wd)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, rel)
+
+		if rel == "api" {
+			return errors.New("sync failed")
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		t.Error("expected workspace errors")
+	}
+
+	if ran != 2 {
+		t.Errorf("expected 2 catalogs run, got %d", ran)
+	}
+
+	// Catalogs run in their own folder in path order; the worker
+	// catalog has no dev files and is skipped.
+	if expected := []string{"api", filepath.Join("services", "web")}; !reflect.DeepEqual(dirs, expected) {
+		t.Errorf("expected catalogs run in %v, got %v", expected, dirs)
+	}
+
+	// Errors are grouped by catalog; the broken catalog and the
+	// failed run are both reported.
+	if len(dep.Monitor.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", dep.Monitor.Errors)
+	}
+
+	for i, prefix := range []string{"api/stash.yml: ", "broken/stash.yml: "} {
+		if msg := dep.Monitor.Errors[i].Error(); !strings.HasPrefix(msg, prefix) {
+			t.Errorf("expected error for %s, got %s", prefix, msg)
+		}
+	}
+
+	if wd, err := os.Getwd(); err != nil || wd != root {
+		t.Errorf("expected the working directory %s restored, got %s %v", root, wd, err)
+	}
+}
@@ -32,6 +32,36 @@ type File struct {
 	// Clean deletes the local files after changes have been pushed
 	// to the remote service.
 	Clean bool `yaml:"clean,omitempty"`
+
+	// Extends is the catalog key of a base entry whose options and
+	// tags are inherited.
+	Extends string `yaml:"extends,omitempty"`
+
+	// Source is the included catalog defining the entry and
+	// SourceContext is its context. Both are empty for entries
+	// defined by the catalog read.
+	Source        string `yaml:"-"`
+	SourceContext string `yaml:"-"`
+
+	inheritedOptions map[string]string
+	inheritedTags    []string
+}
+
+// ResolveContext returns the context of the catalog defining the
+// file. Included files keep the context they were synced with.
+func (f File) ResolveContext(context string) string {
+	if len(f.SourceContext) > 0 {
+		return f.SourceContext
+	}
+
+	return context
+}
+
+// Included determines if the file is defined by an included catalog.
+// Paths are relative to the including catalog; so, included files are
+// downloaded there but only synced or purged from their own catalog.
+func (f File) Included() bool {
+	return len(f.Source) > 0
 }
 
 // RemoveTag ...
@@ -63,6 +93,8 @@ func (f *File) AddTag(tag string) {
 // ToServiceModel ...
 func (f File) ToServiceModel(context, key string, remote service.IService, data []byte) (service.File, error) {
 
+	context = f.ResolveContext(context)
+
 	state, err := f.LookupState(context)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -91,7 +123,7 @@ func (f File) ToServiceModel(context, key string, remote service.IService, data
 func (f *File) Matches(filter Filter) bool {
 
 	if len(filter.Files) > 0 {
-		if !slice.AnyIn([]string{f.Path}, filter.Files) {
+		if !f.matchesPath(filter.Files) {
 			return false
 		}
 	}
@@ -108,9 +140,31 @@ func (f *File) Matches(filter Filter) bool {
 		}
 	}
 
+	if filter.Query != nil {
+		if !filter.Query.Match(f) {
+			return false
+		}
+	}
+
 	return true
 }
 
+// matchesPath determines if the file path matches any of the paths or
+// path globs.
+func (f *File) matchesPath(paths []string) bool {
+	if slice.AnyIn([]string{f.Path}, paths) {
+		return true
+	}
+
+	for _, p := range paths {
+		if IsGlob(p) && GlobRegexp(p).MatchString(f.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GroupByService ...
 func GroupByService(files map[string]File) map[string]map[string]File {
 	grouped := map[string]map[string]File{}
@@ -11,11 +11,14 @@ type Filter struct {
 	Tags  []string
 
 	Service string
+
+	// Query is an optional filter expression.
+	Query *Query
 }
 
 // Empty ...
 func (f Filter) Empty() bool {
-	return len(f.Files) == 0 && len(f.Tags) == 0 && len(f.Service) == 0
+	return len(f.Files) == 0 && len(f.Tags) == 0 && len(f.Service) == 0 && f.Query == nil
 }
 
 // Format ...
@@ -34,27 +37,33 @@ func (f Filter) Format(delimiter string) string {
 		b.WriteString(fmt.Sprintf("service[%s]%s", f.Service, delimiter))
 	}
 
+	if f.Query != nil {
+		b.WriteString(fmt.Sprintf("query[%s]%s", f.Query, delimiter))
+	}
+
 	return strings.Trim(b.String(), delimiter)
 }
 
 // NewPushFilter ...
-func NewPushFilter(files, tags []string, service string) Filter {
+func NewPushFilter(files, tags []string, service string, query *Query) Filter {
 	filter := Filter{Files: files}
 
 	if filter.Empty() {
 		filter.Tags = tags
 		filter.Service = service
+		filter.Query = query
 	}
 
 	return filter
 }
 
 // NewGetFilter ...
-func NewGetFilter(files, tags []string, service string) Filter {
+func NewGetFilter(files, tags []string, service string, query *Query) Filter {
 	filter := Filter{
 		Files:   files,
 		Tags:    tags,
 		Service: service,
+		Query:   query,
 	}
 
 	return filter
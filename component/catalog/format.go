@@ -0,0 +1,181 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dabblebox/stash/component/path"
+	"gopkg.in/yaml.v2"
+)
+
+// FormatVersion is the catalog format written by this version of
+// stash. Catalogs without a version, or with the stash release written
+// by earlier versions, are format 1.
+const FormatVersion = 3
+
+// migration upgrades a catalog document to the next format. Documents
+// are migrated before being decoded; so, renamed fields and options
+// are not dropped.
+type migration func(doc map[string]interface{}) error
+
+// migrations upgrade catalogs one format at a time; migrations[i]
+// upgrades format i+1 to i+2.
+var migrations = []migration{
+	migrateV1,
+	migrateV2,
+}
+
+// ErrFormatOutdated is returned by CheckFormat when the catalog needs
+// to be upgraded.
+var ErrFormatOutdated = errors.New("catalog format is outdated, run: stash catalog upgrade")
+
+// Migrate upgrades catalog data to the current format. The format of
+// the original data is returned. Current data is returned unchanged.
+func Migrate(data []byte) ([]byte, int, error) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return data, 0, err
+	}
+
+	from, err := CheckFormat(doc["version"])
+	if err != nil && err != ErrFormatOutdated {
+		return data, from, err
+	}
+
+	if from == FormatVersion {
+		return data, from, nil
+	}
+
+	for v := from; v < FormatVersion; v++ {
+		if err := migrations[v-1](doc); err != nil {
+			return data, from, fmt.Errorf("catalog format %d upgrade failed: %s", v, err)
+		}
+	}
+
+	doc["version"] = FormatVersion
+
+	d, err := yaml.Marshal(doc)
+	if err != nil {
+		return data, from, err
+	}
+
+	return d, from, nil
+}
+
+// CheckFormat returns the catalog format for a version field value.
+// ErrFormatOutdated is returned for older formats and an error for
+// formats newer than this version of stash reads.
+func CheckFormat(version interface{}) (int, error) {
+	v := 1
+
+	switch t := version.(type) {
+	case nil:
+	case int:
+		v = t
+	case float64:
+		// YAML reads 1.0 as a float.
+		if t != math.Trunc(t) {
+			return 0, fmt.Errorf("catalog version %v is not a whole number", version)
+		}
+		v = int(t)
+	case string:
+		// Earlier versions wrote the stash release. (e.g. 1.2.0)
+		if n, err := strconv.Atoi(t); err == nil {
+			v = n
+		}
+	default:
+		return 0, fmt.Errorf("catalog version %v is not a number", version)
+	}
+
+	switch {
+	case v < 1:
+		return v, fmt.Errorf("catalog version %d is invalid", v)
+	case v > FormatVersion:
+		return v, fmt.Errorf("catalog format %d is newer than format %d, upgrade stash", v, FormatVersion)
+	case v < FormatVersion:
+		return v, ErrFormatOutdated
+	}
+
+	return v, nil
+}
+
+// migrateV1 upgrades catalogs written before the format was
+// versioned. File types could be omitted and were never inferred.
+func migrateV1(doc map[string]interface{}) error {
+	return eachFile(doc, func(f map[interface{}]interface{}) error {
+		if t, _ := f["type"].(string); len(t) > 0 {
+			return nil
+		}
+
+		if p, ok := f["path"].(string); ok {
+			f["type"] = path.Type(p)
+		}
+
+		return nil
+	})
+}
+
+// migrateV2 upgrades catalogs written before include and extends.
+// The documents are unchanged; the format keeps earlier versions of
+// stash from ignoring the included and extended entries.
+func migrateV2(doc map[string]interface{}) error {
+	return nil
+}
+
+// eachFile calls fn with every file in the catalog document.
+func eachFile(doc map[string]interface{}, fn func(f map[interface{}]interface{}) error) error {
+	if doc["files"] == nil {
+		return nil
+	}
+
+	files, ok := doc["files"].(map[interface{}]interface{})
+	if !ok {
+		return errors.New("files is not a map")
+	}
+
+	for k, v := range files {
+		f, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return fmt.Errorf("file %v is not a map", k)
+		}
+
+		if err := fn(f); err != nil {
+			return fmt.Errorf("file %v: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// renameOptions renames file options for a service. Migrations use it
+// when option names change.
+func renameOptions(doc map[string]interface{}, serviceKey string, names map[string]string) error {
+	return eachFile(doc, func(f map[interface{}]interface{}) error {
+		if f["service"] != serviceKey || f["opt"] == nil {
+			return nil
+		}
+
+		opt, ok := f["opt"].(map[interface{}]interface{})
+		if !ok {
+			return errors.New("opt is not a map")
+		}
+
+		for old, name := range names {
+			v, ok := opt[old]
+			if !ok {
+				continue
+			}
+
+			if _, exists := opt[name]; exists {
+				return fmt.Errorf("options %s and %s are both set", old, name)
+			}
+
+			opt[name] = v
+			delete(opt, old)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestMigrate(t *testing.T) {
+	data, from, err := Migrate([]byte(`
+version: 1.4.0
+context: app
+files:
+  config-env:
+    path: config/.env
+    service: secrets-manager
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if from != 1 {
+		t.Errorf("expected format 1, got %d", from)
+	}
+
+	c := Catalog{}
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Version != FormatVersion || c.Files["config-env"].Type != "env" {
+		t.Errorf("unexpected upgrade %+v", c)
+	}
+
+	current := []byte("version: 3\ncontext: app\n")
+	if data, _, err := Migrate(current); err != nil || string(data) != string(current) {
+		t.Errorf("expected current format unchanged, got %q %v", data, err)
+	}
+
+	if _, _, err := Migrate([]byte("version: 4\n")); err == nil {
+		t.Error("expected newer format error")
+	}
+}
+
+func TestRenameOptions(t *testing.T) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(`
+files:
+  a:
+    service: s3
+    opt:
+      bucket: configs
+  b:
+    service: parameter-store
+    opt:
+      bucket: configs
+`), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := renameOptions(doc, "s3", map[string]string{"bucket": "s3_bucket"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files := doc["files"].(map[interface{}]interface{})
+
+	a := files["a"].(map[interface{}]interface{})["opt"]
+	if !reflect.DeepEqual(a, map[interface{}]interface{}{"s3_bucket": "configs"}) {
+		t.Errorf("expected option renamed, got %v", a)
+	}
+
+	b := files["b"].(map[interface{}]interface{})["opt"]
+	if !reflect.DeepEqual(b, map[interface{}]interface{}{"bucket": "configs"}) {
+		t.Errorf("expected other services unchanged, got %v", b)
+	}
+}
+
+func TestCheckFormat(t *testing.T) {
+	cases := []struct {
+		version  string
+		expected int
+		err      error
+		invalid  bool
+	}{
+		{version: "", expected: 1, err: ErrFormatOutdated},
+		{version: "1.4.0", expected: 1, err: ErrFormatOutdated},
+		{version: "2", expected: 2, err: ErrFormatOutdated},
+		{version: "3", expected: FormatVersion},
+		{version: "3.0", expected: FormatVersion},
+		{version: "3.5", invalid: true},
+		{version: "4", expected: 4, invalid: true},
+		{version: "0", expected: 0, invalid: true},
+		{version: "[3]", invalid: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			doc := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte("version: "+c.version), &doc); err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := CheckFormat(doc["version"])
+
+			if c.invalid {
+				if err == nil || err == ErrFormatOutdated {
+					t.Errorf("expected an invalid format error, got %v", err)
+				}
+				return
+			}
+
+			if err != c.err || actual != c.expected {
+				t.Errorf("expected %d %v, got %d %v", c.expected, c.err, actual, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/slice"
+	"gopkg.in/yaml.v2"
+)
+
+// resolve adds the entries of included catalogs and applies the base
+// entries extended by each entry.
+func (c *Catalog) resolve(catalogFile string) error {
+	if c.Files == nil {
+		c.Files = map[string]File{}
+	}
+
+	c.included = map[string]Catalog{}
+
+	owners := map[string]string{}
+	for k := range c.Files {
+		owners[k] = catalogFile
+	}
+
+	abs, err := filepath.Abs(catalogFile)
+	if err != nil {
+		return err
+	}
+
+	read := map[string]bool{abs: true}
+
+	if err := c.include(catalogFile, c.Include, owners, []string{abs}, read); err != nil {
+		return err
+	}
+
+	return c.extend()
+}
+
+// include reads the included catalogs relative to the including
+// catalog. Entries keep the context of the catalog defining them.
+// Catalogs included through more than one catalog are read once; only
+// catalogs including themselves through the chain are rejected.
+func (c *Catalog) include(catalogFile string, includes []string, owners map[string]string, chain []string, read map[string]bool) error {
+	for _, i := range includes {
+		p := filepath.Join(filepath.Dir(catalogFile), i)
+
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+
+		if slice.In(abs, chain) {
+			return fmt.Errorf("%s includes itself: %s", p, strings.Join(append(chain, abs), " > "))
+		}
+
+		if read[abs] {
+			continue
+		}
+		read[abs] = true
+
+		ic, err := readIncluded(p)
+		if err != nil {
+			return fmt.Errorf("include %s: %s", p, err)
+		}
+
+		c.included[p] = ic
+
+		for k, f := range ic.Files {
+			if owner, found := owners[k]; found {
+				return fmt.Errorf("catalog key %s is defined in %s and %s", k, owner, p)
+			}
+			owners[k] = p
+
+			f.Source = p
+			f.SourceContext = ic.Context
+
+			c.Files[k] = f
+		}
+
+		if err := c.include(p, ic.Include, owners, append(chain, abs), read); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readIncluded reads a catalog without environment overrides. The
+// overrides apply to the catalog being read.
+func readIncluded(catalogFile string) (Catalog, error) {
+	data, err := file.Read(catalogFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Catalog{}, os.ErrNotExist
+		}
+
+		return Catalog{}, err
+	}
+
+	data, _, err = Migrate(data)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	c := Catalog{}
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return Catalog{}, err
+	}
+
+	if len(c.Context) == 0 {
+		return Catalog{}, fmt.Errorf("context is required")
+	}
+
+	return c, nil
+}
+
+// extend merges the options and tags of base entries into the entries
+// extending them. Options set by an entry override the base options.
+func (c *Catalog) extend() error {
+	resolved := map[string]bool{}
+
+	var resolve func(key string, chain []string) error
+	resolve = func(key string, chain []string) error {
+		f := c.Files[key]
+
+		if resolved[key] || len(f.Extends) == 0 {
+			return nil
+		}
+
+		if slice.In(key, chain) {
+			return fmt.Errorf("catalog key %s extends itself: %s", key, strings.Join(append(chain, key), " > "))
+		}
+
+		if _, ok := c.Files[f.Extends]; !ok {
+			return fmt.Errorf("catalog key %s extends %s which is not found", key, f.Extends)
+		}
+
+		if err := resolve(f.Extends, append(chain, key)); err != nil {
+			return err
+		}
+
+		base := c.Files[f.Extends]
+
+		f.inheritedOptions = map[string]string{}
+		f.inheritedTags = []string{}
+
+		options := map[string]string{}
+		for k, v := range base.Options {
+			options[k] = v
+
+			if _, ok := f.Options[k]; !ok {
+				f.inheritedOptions[k] = v
+			}
+		}
+
+		for k, v := range f.Options {
+			options[k] = v
+		}
+
+		tags := append([]string{}, base.Tags...)
+		for _, t := range f.Tags {
+			if !slice.In(t, tags) {
+				tags = append(tags, t)
+			}
+		}
+
+		for _, t := range base.Tags {
+			if !slice.In(t, f.Tags) {
+				f.inheritedTags = append(f.inheritedTags, t)
+			}
+		}
+
+		f.Options = options
+		f.Tags = tags
+
+		c.Files[key] = f
+		resolved[key] = true
+
+		return nil
+	}
+
+	keys := []string{}
+	for k := range c.Files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := resolve(k, []string{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Own returns the entries defined by the catalog excluding included
+// entries.
+func (c *Catalog) Own() map[string]File {
+	files := map[string]File{}
+
+	for k, f := range c.Files {
+		if len(f.Source) == 0 {
+			files[k] = f
+		}
+	}
+
+	return files
+}
+
+// unresolved removes the options and tags inherited from the base
+// entry; so, they are not written to the entry's catalog.
+func (f File) unresolved() File {
+	if len(f.Extends) == 0 {
+		return f
+	}
+
+	options := map[string]string{}
+	for k, v := range f.Options {
+		if iv, ok := f.inheritedOptions[k]; ok && iv == v {
+			continue
+		}
+
+		options[k] = v
+	}
+
+	tags := []string{}
+	for _, t := range f.Tags {
+		if !slice.In(t, f.inheritedTags) {
+			tags = append(tags, t)
+		}
+	}
+
+	f.Options = options
+	f.Tags = tags
+
+	return f
+}
+
+// split groups the entries by the catalog file defining them. Every
+// included catalog is returned even when its entries were removed.
+func (c Catalog) split(catalogFile string) map[string]Catalog {
+	catalogs := map[string]Catalog{}
+
+	root := c
+	root.Files = map[string]File{}
+	catalogs[catalogFile] = root
+
+	for p, ic := range c.included {
+		ic.Files = map[string]File{}
+		catalogs[p] = ic
+	}
+
+	for k, f := range c.Files {
+		owner, ok := catalogs[f.Source]
+		if !ok {
+			owner = catalogs[catalogFile]
+		}
+
+		owner.Files[k] = f.unresolved()
+	}
+
+	return catalogs
+}
+
+// changed determines if the included catalog entries differ from the
+// entries read.
+func (c Catalog) changed(catalogFile string, files map[string]File) bool {
+	ic, ok := c.included[catalogFile]
+	if !ok {
+		return true
+	}
+
+	a, err := yaml.Marshal(ic.Files)
+	if err != nil {
+		return true
+	}
+
+	b, err := yaml.Marshal(files)
+	if err != nil {
+		return true
+	}
+
+	return !bytes.Equal(a, b)
+}
@@ -0,0 +1,131 @@
+package catalog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-include")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, data string) string {
+		p := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		return p
+	}
+
+	shared := write("shared/stash.yml", `
+version: 3
+context: shared
+files:
+  observability-env:
+    path: observability.env
+    type: env
+    service: secrets-manager
+    opt:
+      kms_key_id: shared
+    keys:
+    - shared/observability.env
+    tags: [observability]
+`)
+
+	root := write("api/stash.yml", `
+version: 3
+context: api
+include:
+- ../shared/stash.yml
+files:
+  config-env:
+    path: config.env
+    type: env
+    service: secrets-manager
+    extends: observability-env
+    opt:
+      secrets: multiple
+    tags: [api]
+`)
+
+	before, err := ioutil.ReadFile(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Read(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := c.Files["observability-env"]
+	if o.Source != filepath.Join(dir, "shared/stash.yml") || o.ResolveContext(c.Context) != "shared" {
+		t.Errorf("unexpected included file %+v", o)
+	}
+
+	f := c.Files["config-env"]
+	if !reflect.DeepEqual(f.Options, map[string]string{"kms_key_id": "shared", "secrets": "multiple"}) {
+		t.Errorf("unexpected extended options %v", f.Options)
+	}
+
+	if !reflect.DeepEqual(f.Tags, []string{"observability", "api"}) {
+		t.Errorf("unexpected extended tags %v", f.Tags)
+	}
+
+	// Unchanged included catalogs are not written.
+	if err := Save(root, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if after, _ := ioutil.ReadFile(shared); string(after) != string(before) {
+		t.Errorf("unchanged included catalog written\n%s", after)
+	}
+
+	if d, _ := ioutil.ReadFile(root); strings.Contains(string(d), "observability.env") || strings.Contains(string(d), "kms_key_id") {
+		t.Errorf("included or inherited values written to the catalog\n%s", d)
+	}
+
+	o.Keys = []string{"shared/observability.env/v2"}
+	c.Files["observability-env"] = o
+
+	if err := Save(root, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if after, _ := ioutil.ReadFile(shared); !strings.Contains(string(after), "shared/observability.env/v2") {
+		t.Errorf("included catalog not updated\n%s", after)
+	}
+
+	write("loop/stash.yml", "version: 3\ncontext: loop\ninclude: [stash.yml]\n")
+	if _, err := Read(filepath.Join(dir, "loop/stash.yml")); err == nil {
+		t.Error("expected include cycle error")
+	}
+
+	// Catalogs included by sibling catalogs are read once.
+	write("diamond/base.yml", "version: 3\ncontext: base\nfiles:\n  base-env:\n    path: base.env\n    service: secrets-manager\n")
+	write("diamond/a.yml", "version: 3\ncontext: a\ninclude: [base.yml]\n")
+	write("diamond/b.yml", "version: 3\ncontext: b\ninclude: [base.yml]\n")
+	write("diamond/stash.yml", "version: 3\ncontext: app\ninclude: [a.yml, b.yml]\n")
+
+	d, err := Read(filepath.Join(dir, "diamond/stash.yml"))
+	if err != nil {
+		t.Fatalf("diamond include: %s", err)
+	}
+
+	if _, ok := d.Files["base-env"]; !ok || len(d.Files) != 1 {
+		t.Errorf("unexpected diamond files %v", d.Files)
+	}
+}
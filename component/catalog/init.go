@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/dabblebox/stash/component/file"
 	"github.com/spf13/viper"
 )
 
@@ -20,21 +22,37 @@ func initViperCatalog(catalog string) *viper.Viper {
 	v.SetConfigFile(catalog)
 	v.SetConfigType("yaml")
 	v.AddConfigPath(".")
-	v.SetDefault("version", viper.GetString("version"))
+	v.SetDefault("version", FormatVersion)
 
 	return v
 }
 
+// readViperCatalog upgrades the catalog file to the current format
+// before it is decoded; so, older catalogs are never misread.
+func readViperCatalog(v *viper.Viper, catalogFile string) error {
+	data, err := file.Read(catalogFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return os.ErrNotExist
+		}
+
+		return err
+	}
+
+	data, _, err = Migrate(data)
+	if err != nil {
+		return err
+	}
+
+	return v.ReadConfig(bytes.NewReader(data))
+}
+
 // Read loads an existing catalog file.
 func Read(catalogFile string) (Catalog, error) {
 
 	v := initViperCatalog(catalogFile)
 
-	if err := v.ReadInConfig(); err != nil {
-		if _, notFound := err.(viper.ConfigFileNotFoundError); notFound || os.IsNotExist(err) {
-			return Catalog{}, os.ErrNotExist
-		}
-
+	if err := readViperCatalog(v, catalogFile); err != nil {
 		return Catalog{}, err
 	}
 
@@ -44,6 +62,10 @@ func Read(catalogFile string) (Catalog, error) {
 		return Catalog{}, fmt.Errorf("Unable to decode into struct, %v", err)
 	}
 
+	if err := catalog.resolve(catalogFile); err != nil {
+		return Catalog{}, err
+	}
+
 	return catalog, nil
 }
 
@@ -59,8 +81,8 @@ func Init(context, catalogFile string, dep InitDep) (Catalog, error) {
 
 	v := initViperCatalog(catalogFile)
 
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok && !os.IsNotExist(err) {
+	if err := readViperCatalog(v, catalogFile); err != nil {
+		if !os.IsNotExist(err) {
 			return Catalog{}, err
 		}
 
@@ -110,6 +132,10 @@ func Init(context, catalogFile string, dep InitDep) (Catalog, error) {
 		return Catalog{}, fmt.Errorf("Unable to decode into struct, %v", err)
 	}
 
+	if err := catalog.resolve(catalogFile); err != nil {
+		return Catalog{}, err
+	}
+
 	return catalog, nil
 }
 
@@ -12,13 +12,21 @@ const DefaultName = "stash.yml"
 
 // Catalog is reference file for the stashed configuration files.
 type Catalog struct {
-	Version string `yaml:"version"`
+	// Version is the catalog format. (see FormatVersion)
+	Version int `yaml:"version"`
 
 	Context string `yaml:"context"`
 
+	// Include lists catalogs whose entries are added to this catalog.
+	// Paths are relative to this catalog.
+	Include []string `yaml:"include,omitempty"`
+
 	AutoClean bool `yaml:"clean" mapstructure:"clean"`
 
 	Files map[string]File `yaml:"files"`
+
+	// included are the catalogs read by Include keyed by path.
+	included map[string]Catalog
 }
 
 // GetFile ...
@@ -63,6 +71,63 @@ func (c *Catalog) AddFile(key, filePath, service string, tags []string) error {
 	return nil
 }
 
+// ImportFile catalogs a file discovered in a remote service.
+func (c *Catalog) ImportFile(serviceKey string, sf service.File, tags []string) (string, error) {
+	key := formatInitialKey(sf.LocalPath)
+
+	if err := c.AddFile(key, sf.LocalPath, serviceKey, tags); err != nil {
+		return key, err
+	}
+
+	f := c.Files[key]
+	f.Type = sf.Type
+	f.Keys = sf.Keys
+	f.Options = sf.Options
+
+	c.Files[key] = f
+
+	return key, nil
+}
+
+// MoveFile changes the local path of a cataloged file. Catalog keys
+// generated from the original path are regenerated while custom keys
+// are kept. The new catalog key is returned.
+func (c *Catalog) MoveFile(key, filePath string) (string, error) {
+	f, ok := c.Files[key]
+	if !ok {
+		return key, fmt.Errorf("catalog key %s not found", key)
+	}
+
+	newKey := key
+	if key == formatInitialKey(f.Path) {
+		newKey = formatInitialKey(filePath)
+	}
+
+	if _, found := c.Files[newKey]; found && newKey != key {
+		return key, fmt.Errorf("catalog key %s already exists", newKey)
+	}
+
+	f.Path = filePath
+
+	delete(c.Files, key)
+	c.Files[newKey] = f
+
+	return newKey, nil
+}
+
+// LookupRemoteKey finds the catalog file tracking the remote key.
+func (c *Catalog) LookupRemoteKey(remoteKey string) (File, bool) {
+	for _, f := range c.Files {
+		for _, k := range f.Keys {
+			if k == remoteKey {
+				return f, true
+			}
+		}
+	}
+
+	return File{}, false
+}
+
 // Filter ...
 func (c *Catalog) Filter(filter Filter) map[string]File {
 	filtered := map[string]File{}
@@ -0,0 +1,340 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Query fields matched against catalog files.
+const (
+	QueryTag     = "tag"
+	QueryService = "service"
+	QueryType    = "type"
+	QueryPath    = "path"
+	QueryKey     = "key"
+)
+
+var queryFields = []string{QueryTag, QueryService, QueryType, QueryPath, QueryKey}
+
+// Query is a parsed filter expression combining field terms with and,
+// or, not, and parentheses. Values are globs where "*" does not match
+// "/" and "**" does. (e.g. tag:prod and (service:s3 or type:json) and
+// not path:certs/**)
+type Query struct {
+	expr string
+	root queryNode
+}
+
+type queryNode interface {
+	match(f *File) bool
+}
+
+type queryAnd []queryNode
+
+func (n queryAnd) match(f *File) bool {
+	for _, c := range n {
+		if !c.match(f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type queryOr []queryNode
+
+func (n queryOr) match(f *File) bool {
+	for _, c := range n {
+		if c.match(f) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type queryNot struct {
+	node queryNode
+}
+
+func (n queryNot) match(f *File) bool {
+	return !n.node.match(f)
+}
+
+type queryTerm struct {
+	field string
+	glob  *regexp.Regexp
+}
+
+func (n queryTerm) match(f *File) bool {
+	values := []string{}
+
+	switch n.field {
+	case QueryTag:
+		values = f.Tags
+	case QueryService:
+		values = []string{f.Service}
+	case QueryType:
+		values = []string{f.Type}
+	case QueryPath:
+		values = []string{f.Path}
+	case QueryKey:
+		values = f.Keys
+	}
+
+	for _, v := range values {
+		if n.glob.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseQuery parses a filter expression.
+func ParseQuery(expr string) (*Query, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	p := queryParser{tokens: tokens}
+
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("query: unexpected %q", p.tokens[p.pos])
+	}
+
+	return &Query{expr: expr, root: root}, nil
+}
+
+// Match determines if the file matches the query.
+func (q *Query) Match(f *File) bool {
+	return q.root.match(f)
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *queryParser) keyword(word string) bool {
+	if strings.EqualFold(p.peek(), word) {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+// or := and ("or" and)*
+func (p *queryParser) or() (queryNode, error) {
+	n, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := queryOr{n}
+	for p.keyword("or") {
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+
+	return nodes, nil
+}
+
+// and := not ("and" not)*
+func (p *queryParser) and() (queryNode, error) {
+	n, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := queryAnd{n}
+	for p.keyword("and") {
+		n, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, n)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+
+	return nodes, nil
+}
+
+// not := "not" not | "(" or ")" | field:value
+func (p *queryParser) not() (queryNode, error) {
+	if p.keyword("not") {
+		n, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+
+		return queryNot{node: n}, nil
+	}
+
+	t := p.peek()
+
+	switch t {
+	case "":
+		return nil, fmt.Errorf("query: unexpected end")
+	case ")":
+		return nil, fmt.Errorf("query: unexpected %q", t)
+	case "(":
+		p.pos++
+
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("query: missing )")
+		}
+		p.pos++
+
+		return n, nil
+	}
+
+	p.pos++
+
+	return parseQueryTerm(t)
+}
+
+func parseQueryTerm(t string) (queryNode, error) {
+	i := strings.Index(t, ":")
+	if i == -1 {
+		return nil, fmt.Errorf("query: %q is not field:value", t)
+	}
+
+	field, value := strings.ToLower(t[:i]), t[i+1:]
+
+	known := false
+	for _, f := range queryFields {
+		known = known || f == field
+	}
+
+	if !known {
+		return nil, fmt.Errorf("query: field %q is not one of %s", field, strings.Join(queryFields, ", "))
+	}
+
+	if len(value) == 0 {
+		return nil, fmt.Errorf("query: %s value is empty", field)
+	}
+
+	return queryTerm{field: field, glob: GlobRegexp(value)}, nil
+}
+
+// tokenizeQuery splits the expression into parentheses and words.
+// Quoted values may contain spaces. (e.g. path:"my config/*")
+func tokenizeQuery(expr string) ([]string, error) {
+	tokens := []string{}
+
+	var b strings.Builder
+	quote := rune(0)
+
+	flush := func() {
+		if b.Len() > 0 {
+			tokens = append(tokens, b.String())
+			b.Reset()
+		}
+	}
+
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+
+			b.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		return tokens, fmt.Errorf("query: missing closing %c", quote)
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+// GlobRegexp converts a glob to a regular expression. "*" and "?" do
+// not match "/" while "**" does.
+func GlobRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+				continue
+			}
+
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String())
+}
+
+// IsGlob determines if the value contains glob characters.
+func IsGlob(value string) bool {
+	return strings.ContainsAny(value, "*?")
+}
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	files := map[string]File{
+		"prod-env":  {Path: "config/prod/.env", Type: "env", Service: "secrets-manager", Tags: []string{"config", "prod"}, Keys: []string{"app/config/prod/.env"}},
+		"prod-json": {Path: "config/prod/app.json", Type: "json", Service: "parameter-store", Tags: []string{"config", "prod"}},
+		"prod-cert": {Path: "certs/prod/tls.pem", Type: "pem", Service: "s3", Tags: []string{"certs", "prod"}},
+		"legacy":    {Path: "config/prod/old.json", Type: "json", Service: "s3", Tags: []string{"prod", "legacy"}},
+		"dev-env":   {Path: "config/dev/.env", Type: "env", Service: "secrets-manager", Tags: []string{"config", "dev"}},
+	}
+
+	tests := []struct {
+		expr    string
+		matches []string
+	}{
+		{"tag:prod and (service:s3 or type:json) and not tag:legacy", []string{"prod-cert", "prod-json"}},
+		{"tag:prod and not path:certs/**", []string{"legacy", "prod-env", "prod-json"}},
+		{"path:config/*/.env", []string{"dev-env", "prod-env"}},
+		{"path:config/*", []string{}},
+		{"key:app/**", []string{"prod-env"}},
+		{"TYPE:env OR type:pem", []string{"dev-env", "prod-cert", "prod-env"}},
+		{"not not tag:dev", []string{"dev-env"}},
+		{"tag:'prod' and service:\"s3\"", []string{"legacy", "prod-cert"}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.expr)
+		if err != nil {
+			t.Errorf("%s: %s", tt.expr, err)
+			continue
+		}
+
+		matches := []string{}
+		for _, k := range sortedFileKeys(files) {
+			f := files[k]
+			if q.Match(&f) {
+				matches = append(matches, k)
+			}
+		}
+
+		if len(matches) != len(tt.matches) {
+			t.Errorf("%s: expected %v, got %v", tt.expr, tt.matches, matches)
+			continue
+		}
+
+		for i := range matches {
+			if matches[i] != tt.matches[i] {
+				t.Errorf("%s: expected %v, got %v", tt.expr, tt.matches, matches)
+				break
+			}
+		}
+	}
+
+	for _, expr := range []string{"", "tag:", "prod", "owner:me", "(tag:prod", "tag:prod)", "tag:prod and", "not", "tag:'prod"} {
+		if _, err := ParseQuery(expr); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
+
+func TestMatchesPathGlob(t *testing.T) {
+	f := File{Path: "config/prod/.env"}
+
+	if !f.Matches(Filter{Files: []string{"config/**"}}) {
+		t.Error("expected path glob match")
+	}
+
+	if f.Matches(Filter{Files: []string{"config/*.env"}}) {
+		t.Error("unexpected path glob match")
+	}
+}
+
+func sortedFileKeys(files map[string]File) []string {
+	keys := []string{}
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
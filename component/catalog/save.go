@@ -6,8 +6,24 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// Save ...
+// Save writes the catalog. Included entries are written to the
+// catalogs defining them when changed.
 func Save(file string, c Catalog) error {
+	for p, oc := range c.split(file) {
+		if p != file && !c.changed(p, oc.Files) {
+			continue
+		}
+
+		if err := write(p, oc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func write(file string, c Catalog) error {
+	c.Version = FormatVersion
 
 	d, err := yaml.Marshal(&c)
 	if err != nil {
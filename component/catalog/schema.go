@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"encoding/json"
+
+	"github.com/dabblebox/stash/component/service"
+)
+
+// Schema returns a JSON Schema for the catalog; so, editors can
+// validate catalogs as they are written. Options are generated from
+// the options read by each service.
+func Schema() ([]byte, error) {
+	stringList := map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"type": "string"},
+	}
+
+	services := []interface{}{}
+	for _, serviceKey := range serviceKeys() {
+		services = append(services, map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"service": map[string]interface{}{"const": serviceKey},
+				},
+			},
+			"then": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"opt": optionsSchema(serviceKey),
+				},
+			},
+		})
+	}
+
+	schema := map[string]interface{}{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"title":                "Stash Catalog",
+		"type":                 "object",
+		"required":             []string{"version", "context"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"version": map[string]interface{}{
+				"description": "Catalog format. Older formats are upgraded by: stash catalog upgrade",
+				"const":       FormatVersion,
+			},
+			"context": map[string]interface{}{
+				"description": "Prefix for stashed data keys providing application or repository context.",
+				"type":        "string",
+				"minLength":   1,
+			},
+			"clean": map[string]interface{}{
+				"description": "Delete local files after they are synced.",
+				"type":        "boolean",
+			},
+			"include": map[string]interface{}{
+				"description": "Catalogs whose files are added to this catalog. Paths are relative to this catalog.",
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+			},
+			"files": map[string]interface{}{
+				"type": "object",
+				"additionalProperties": map[string]interface{}{
+					"type":                 "object",
+					"required":             []string{"path", "service"},
+					"additionalProperties": false,
+					"properties": map[string]interface{}{
+						"path": map[string]interface{}{
+							"description": "Local file location.",
+							"type":        "string",
+							"minLength":   1,
+						},
+						"type": map[string]interface{}{
+							"description": "Local file type. (e.g. env, json, txt)",
+							"type":        "string",
+						},
+						"service": map[string]interface{}{
+							"description": "Remote service.",
+							"enum":        serviceKeys(),
+						},
+						"opt": map[string]interface{}{
+							"description":          "Service options.",
+							"type":                 "object",
+							"additionalProperties": map[string]interface{}{"type": "string"},
+						},
+						"keys":  stringList,
+						"tags":  stringList,
+						"clean": map[string]interface{}{"type": "boolean"},
+						"extends": map[string]interface{}{
+							"description": "Catalog key of a base file whose options and tags are inherited.",
+							"type":        "string",
+						},
+					},
+					"allOf": services,
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func optionsSchema(serviceKey string) map[string]interface{} {
+	properties := map[string]interface{}{}
+
+	for _, o := range service.Options(serviceKey) {
+		p := map[string]interface{}{"type": "string"}
+
+		if values, ok := service.OptionValues(o); ok {
+			p["enum"] = values
+		}
+
+		properties[o] = p
+	}
+
+	return map[string]interface{}{
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
@@ -23,7 +23,7 @@ func (f *File) RecordState(context string) error {
 	}
 
 	// Add seconds to account for remote service timestamp delays.
-	files[formatStateKey(context, f.Path)] = State{
+	files[formatStateKey(f.ResolveContext(context), f.Path)] = State{
 		Synced: time.Now().UTC().Add(10 * time.Second),
 	}
 
@@ -48,7 +48,7 @@ func (f *File) LookupState(context string) (State, error) {
 		return State{}, err
 	}
 
-	if s, ok := files[formatStateKey(context, f.Path)]; ok {
+	if s, ok := files[formatStateKey(f.ResolveContext(context), f.Path)]; ok {
 		return s, nil
 	}
 
@@ -62,3 +62,33 @@ func formatStateKey(context, path string) string {
 type State struct {
 	Synced time.Time
 }
+
+// RemoveState ...
+func (f *File) RemoveState(context string) error {
+	files := map[string]State{}
+
+	b, err := file.Read(file.HomePath(fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err = yaml.Unmarshal(b, &files); err != nil {
+		return err
+	}
+
+	if _, ok := files[formatStateKey(f.ResolveContext(context), f.Path)]; !ok {
+		return nil
+	}
+
+	delete(files, formatStateKey(f.ResolveContext(context), f.Path))
+
+	b, err = yaml.Marshal(files)
+	if err != nil {
+		return err
+	}
+
+	return file.Write(file.HomePath(fileName), b)
+}
@@ -0,0 +1,172 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dabblebox/stash/component/path"
+	"github.com/dabblebox/stash/component/service"
+	"gopkg.in/yaml.v2"
+)
+
+// Problem is an issue found in the catalog. Warnings do not prevent
+// the catalog from being used.
+type Problem struct {
+	// Key is the catalog file key or empty for catalog fields.
+	Key string
+
+	Err     error
+	Warning bool
+}
+
+// ValidateSchema strictly decodes the catalog reporting unknown fields
+// and values with the wrong type. Older formats are upgraded before
+// being decoded and reported as warnings.
+func ValidateSchema(data []byte) []Problem {
+	problems := []Problem{}
+
+	data, from, err := Migrate(data)
+	if err != nil {
+		return append(problems, Problem{Err: err})
+	}
+
+	if from < FormatVersion {
+		problems = append(problems, Problem{Err: fmt.Errorf("format %d: %s", from, ErrFormatOutdated), Warning: true})
+	}
+
+	c := Catalog{}
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		if te, ok := err.(*yaml.TypeError); ok {
+			for _, e := range te.Errors {
+				problems = append(problems, Problem{Err: errors.New(e)})
+			}
+
+			return problems
+		}
+
+		problems = append(problems, Problem{Err: err})
+	}
+
+	return problems
+}
+
+// Validate reports problems that would otherwise surface when files
+// are synced or restored.
+func (c Catalog) Validate() []Problem {
+	problems := []Problem{}
+
+	if len(c.Context) == 0 {
+		problems = append(problems, Problem{Err: errors.New("context is required")})
+	}
+
+	keys := []string{}
+	for k := range c.Files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	paths := map[string][]string{}
+
+	for _, k := range keys {
+		f := c.Files[k]
+
+		add := func(warning bool, format string, a ...interface{}) {
+			problems = append(problems, Problem{Key: k, Err: fmt.Errorf(format, a...), Warning: warning})
+		}
+
+		if len(f.Path) == 0 {
+			add(false, "path is required")
+		} else {
+			p := filepath.Clean(f.Path)
+			paths[p] = append(paths[p], k)
+		}
+
+		remote, ok := service.Services[f.Service]
+		if !ok {
+			add(false, "service %q is not one of %s", f.Service, strings.Join(serviceKeys(), ", "))
+			continue
+		}
+
+		if len(f.Type) > 0 && !remote.Compatible([]string{f.Type}) {
+			add(false, "type %s is not supported by %s", f.Type, f.Service)
+		}
+
+		for _, err := range service.ValidateOptions(f.Service, f.Options, len(f.Keys) > 0) {
+			add(false, err.Error())
+		}
+
+		if len(f.Keys) == 0 {
+			add(true, "keys are empty, the file has not been synced")
+		}
+
+		if context := f.ResolveContext(c.Context); len(f.Path) > 0 && len(context) > 0 {
+			remoteKey := service.FormatObjectKey(context, f.Path, remote)
+
+			// Imported S3 objects keep their key; so, a single key
+			// may be stored outside the context.
+			imported := f.Service == "s3" && len(f.Keys) == 1
+
+			for _, rk := range f.Keys {
+				if rk != remoteKey && !strings.HasPrefix(rk, remoteKey+"/") {
+					if imported {
+						add(true, "key %s is stored outside context %s", rk, context)
+						continue
+					}
+
+					add(false, "key %s does not match %s for context %s", rk, remoteKey, context)
+				}
+			}
+		}
+
+		// Inherited tags are not compared to the path.
+		if tags := f.unresolved().Tags; len(f.Path) > 0 && len(tags) > 0 {
+			if expected := path.Tags(f.Path); len(expected) > 0 && !reflect.DeepEqual(tags, expected) {
+				add(true, "tags [%s] differ from path tags [%s]", strings.Join(tags, ", "), strings.Join(expected, ", "))
+			}
+		}
+	}
+
+	dupes := []string{}
+	for p, ks := range paths {
+		if len(ks) > 1 {
+			dupes = append(dupes, p)
+		}
+	}
+	sort.Strings(dupes)
+
+	for _, p := range dupes {
+		for _, k := range paths[p] {
+			problems = append(problems, Problem{Key: k, Err: fmt.Errorf("path %s is also cataloged under %s", p, strings.Join(otherKeys(paths[p], k), ", "))})
+		}
+	}
+
+	// Group the problems by file.
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+
+	return problems
+}
+
+func otherKeys(keys []string, key string) []string {
+	others := []string{}
+	for _, k := range keys {
+		if k != key {
+			others = append(others, k)
+		}
+	}
+
+	return others
+}
+
+func serviceKeys() []string {
+	keys := []string{}
+	for k := range service.Services {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	problems := ValidateSchema([]byte(`
+version: 3
+context: app
+clean: maybe
+files:
+  config-env:
+    path: config/.env
+    service: secrets-manager
+    secret: multiple
+`))
+
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %+v", problems)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := Catalog{
+		Context: "app",
+		Files: map[string]File{
+			"config-dev-env": {
+				Path:    "config/dev/.env",
+				Type:    "env",
+				Service: "secrets-manager",
+				Keys:    []string{"app/config/dev/.env"},
+				Tags:    []string{"config", "dev"},
+			},
+			"dev-env": {
+				Path:    "./config/dev/.env",
+				Type:    "env",
+				Service: "s3",
+				Options: map[string]string{"secrets": "multiple"},
+				Keys:    []string{"old/config/dev/.env"},
+				Tags:    []string{"dev"},
+			},
+			"prod-env": {
+				Path:    "config/prod/.env",
+				Type:    "env",
+				Service: "secrets-manager",
+				Keys:    []string{"old/config/prod/.env"},
+				Tags:    []string{"config", "prod"},
+			},
+			"notes": {
+				Path:    "notes.txt",
+				Service: "dropbox",
+			},
+		},
+	}
+
+	expected := []string{
+		"config-dev-env: path config/dev/.env is also cataloged under dev-env",
+		"dev-env: option secrets is not supported by s3",
+		"dev-env: option s3_bucket is required by s3",
+		"dev-env: (warning) key old/config/dev/.env is stored outside context app",
+		"dev-env: (warning) tags [dev] differ from path tags [config, dev]",
+		"dev-env: path config/dev/.env is also cataloged under config-dev-env",
+		"notes: service \"dropbox\" is not one of",
+		"prod-env: key old/config/prod/.env does not match app/config/prod/.env for context app",
+	}
+
+	got := []string{}
+	for _, p := range c.Validate() {
+		s := p.Key + ": "
+		if p.Warning {
+			s += "(warning) "
+		}
+		got = append(got, s+p.Err.Error())
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d problems, got:\n%s", len(expected), strings.Join(got, "\n"))
+	}
+
+	for i := range expected {
+		if !strings.HasPrefix(got[i], expected[i]) {
+			t.Errorf("expected %q, got %q", expected[i], got[i])
+		}
+	}
+}
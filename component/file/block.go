@@ -0,0 +1,138 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/dabblebox/stash/component/slice"
+)
+
+const (
+	blockBegin = "# BEGIN STASH MANAGED BLOCK"
+	blockEnd   = "# END STASH MANAGED BLOCK"
+
+	blockOwner = "# Generated by Stash. Changes inside this block are overwritten."
+)
+
+// ReplaceBlock replaces the managed block with the matching id leaving
+// the surrounding content untouched. When the block does not exist, it
+// is appended.
+func ReplaceBlock(data []byte, id string, block []byte) []byte {
+	managed := formatBlock(id, block)
+
+	start, end, found := findBlock(data, id)
+	if !found {
+		if len(data) == 0 {
+			return managed
+		}
+
+		out := append([]byte{}, data...)
+		if !bytes.HasSuffix(out, []byte("\n")) {
+			out = append(out, '\n')
+		}
+
+		return append(append(out, '\n'), managed...)
+	}
+
+	out := append([]byte{}, data[:start]...)
+	out = append(out, managed...)
+
+	return append(out, data[end:]...)
+}
+
+// RemoveBlock removes the managed block with the matching id.
+func RemoveBlock(data []byte, id string) []byte {
+	start, end, found := findBlock(data, id)
+	if !found {
+		return data
+	}
+
+	// Remove the blank line separating the block.
+	if start > 0 && bytes.HasSuffix(data[:start], []byte("\n\n")) {
+		start--
+	}
+
+	out := append([]byte{}, data[:start]...)
+
+	return append(out, data[end:]...)
+}
+
+// Block returns the content of the managed block with the matching id.
+func Block(data []byte, id string) ([]byte, bool) {
+	start, end, found := findBlock(data, id)
+	if !found {
+		return []byte{}, false
+	}
+
+	skip := []string{
+		fmt.Sprintf("%s %s", blockBegin, id),
+		fmt.Sprintf("%s %s", blockEnd, id),
+		blockOwner,
+	}
+
+	// Skip the markers and the owner comment. The end marker may not
+	// end with a newline.
+	content := []string{}
+	for _, l := range strings.SplitAfter(string(data[start:end]), "\n") {
+		if len(l) > 0 && !slice.In(strings.TrimSpace(l), skip) {
+			content = append(content, l)
+		}
+	}
+
+	return []byte(strings.Join(content, "")), true
+}
+
+// BlockIDs lists the managed block ids in order of appearance.
+func BlockIDs(data []byte) []string {
+	ids := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, blockBegin+" ") {
+			ids = append(ids, strings.TrimPrefix(line, blockBegin+" "))
+		}
+	}
+
+	return ids
+}
+
+func formatBlock(id string, block []byte) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(fmt.Sprintf("%s %s\n", blockBegin, id))
+	b.WriteString(blockOwner + "\n")
+	b.Write(bytes.Trim(block, "\n"))
+	b.WriteString(fmt.Sprintf("\n%s %s\n", blockEnd, id))
+
+	return b.Bytes()
+}
+
+// findBlock returns the byte offsets of the managed block including
+// the begin and end markers.
+func findBlock(data []byte, id string) (int, int, bool) {
+	begin := fmt.Sprintf("%s %s", blockBegin, id)
+	end := fmt.Sprintf("%s %s", blockEnd, id)
+
+	start := -1
+	offset := 0
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if start == -1 && trimmed == begin {
+			start = offset
+		}
+
+		offset += len(line)
+
+		if start != -1 && trimmed == end {
+			return start, offset, true
+		}
+	}
+
+	return 0, 0, false
+}
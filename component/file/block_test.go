@@ -0,0 +1,68 @@
+package file
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplaceBlock(t *testing.T) {
+	data := []byte("# user content\n")
+
+	data = ReplaceBlock(data, "a", []byte("first"))
+	data = ReplaceBlock(data, "b", []byte("second"))
+	data = ReplaceBlock(data, "a", []byte("updated"))
+
+	if !reflect.DeepEqual(BlockIDs(data), []string{"a", "b"}) {
+		t.Fatalf("unexpected blocks %v", BlockIDs(data))
+	}
+
+	s := string(data)
+	if !strings.HasPrefix(s, "# user content\n") {
+		t.Errorf("user content not preserved:\n%s", s)
+	}
+
+	if strings.Contains(s, "first") || !strings.Contains(s, "updated") {
+		t.Errorf("block not replaced:\n%s", s)
+	}
+
+	if strings.Index(s, "updated") > strings.Index(s, "second") {
+		t.Errorf("block not replaced in place:\n%s", s)
+	}
+}
+
+func TestRemoveBlock(t *testing.T) {
+	data := ReplaceBlock([]byte("keep\n"), "a", []byte("remove"))
+
+	if got := string(RemoveBlock(data, "a")); got != "keep\n" {
+		t.Errorf("expected only user content, got:\n%s", got)
+	}
+
+	if got := string(RemoveBlock(data, "missing")); got != string(data) {
+		t.Errorf("unexpected change removing missing block:\n%s", got)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	data := ReplaceBlock([]byte("keep\n"), "a", []byte("one\ntwo\n"))
+
+	b, found := Block(data, "a")
+	if !found || string(b) != "one\ntwo\n" {
+		t.Errorf("unexpected block %q", b)
+	}
+
+	if _, found := Block(data, "b"); found {
+		t.Errorf("expected block b not found")
+	}
+
+	// The end marker is the last line without a newline.
+	b, found = Block([]byte("keep\n\n"+blockBegin+" a\n"+blockOwner+"\n/a\n/b\n"+blockEnd+" a"), "a")
+	if !found || string(b) != "/a\n/b\n" {
+		t.Errorf("unexpected block without trailing newline %q", b)
+	}
+
+	b, found = Block([]byte(blockBegin+" a\n"+blockEnd+" a"), "a")
+	if !found || len(b) != 0 {
+		t.Errorf("unexpected empty block %q", b)
+	}
+}
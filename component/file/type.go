@@ -6,6 +6,7 @@ const (
 	TypeXML        = "xml"
 	TypeYML        = "yml"
 	TypeYAML       = "yaml"
+	TypeProperties = "properties"
 	TypeCert       = "cert"
 	TypeSQL        = "sql"
 	TypeJS         = "js"
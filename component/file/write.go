@@ -17,3 +17,19 @@ func Write(path string, b []byte) error {
 
 	return ioutil.WriteFile(path, b, os.ModePerm)
 }
+
+// Append adds the data to the end of the file creating the file
+// when missing.
+func Append(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
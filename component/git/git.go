@@ -0,0 +1,158 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFile is the git ignore file name.
+const IgnoreFile = ".gitignore"
+
+// Root returns the top level directory of the repository. An error is
+// returned when git is not installed or the working directory is not
+// a repository.
+func Root() (string, error) {
+	out, err := run(nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// Ignored returns the paths ignored by git. Tracked files are never
+// ignored.
+func Ignored(paths []string) (map[string]bool, error) {
+	return match(paths, "check-ignore", "--stdin", "-z")
+}
+
+// Tracked returns the paths tracked by git.
+func Tracked(paths []string) (map[string]bool, error) {
+	tracked := map[string]bool{}
+
+	if len(paths) == 0 {
+		return tracked, nil
+	}
+
+	out, err := run(nil, append([]string{"ls-files", "-z", "--"}, paths...)...)
+	if err != nil {
+		return tracked, err
+	}
+
+	// Paths are listed relative to the working directory.
+	for _, p := range strings.Split(out, "\x00") {
+		if len(p) > 0 {
+			tracked[filepath.Clean(p)] = true
+		}
+	}
+
+	return tracked, nil
+}
+
+// NearestIgnoreFile returns the closest .gitignore in the directories
+// between the path and the repository root. When none exist, the root
+// .gitignore is returned.
+func NearestIgnoreFile(path, root string) (string, error) {
+	abs, err := absPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Dir(abs)
+
+	for {
+		f := filepath.Join(dir, IgnoreFile)
+		if _, err := os.Stat(f); err == nil {
+			return f, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir || !strings.HasPrefix(parent, root) {
+			break
+		}
+
+		dir = parent
+	}
+
+	return filepath.Join(root, IgnoreFile), nil
+}
+
+// IgnorePattern returns the pattern in the ignore file matching only
+// the path.
+func IgnorePattern(ignoreFile, path string) (string, error) {
+	abs, err := absPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(ignoreFile), abs)
+	if err != nil {
+		return "", err
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "#", `\#`, "!", `\!`)
+
+	return "/" + r.Replace(filepath.ToSlash(rel)), nil
+}
+
+// absPath resolves symlinks in the directory; so, paths compare with
+// the repository root reported by git.
+func absPath(path string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	if d, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = d
+	}
+
+	return filepath.Join(dir, filepath.Base(path)), nil
+}
+
+// match runs a git command reading NUL separated paths from stdin and
+// returns the paths written to stdout.
+func match(paths []string, args ...string) (map[string]bool, error) {
+	matched := map[string]bool{}
+
+	if len(paths) == 0 {
+		return matched, nil
+	}
+
+	out, err := run(strings.NewReader(strings.Join(paths, "\x00")+"\x00"), args...)
+	if err != nil {
+		return matched, err
+	}
+
+	for _, p := range strings.Split(out, "\x00") {
+		if len(p) > 0 {
+			matched[p] = true
+		}
+	}
+
+	return matched, nil
+}
+
+func run(stdin *strings.Reader, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Exit code 1 means nothing matched. (e.g. no ignored paths)
+		if e, ok := err.(*exec.ExitError); !ok || e.ExitCode() != 1 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()+" "+err.Error()))
+		}
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNearestIgnoreFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "api/config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(root, "api", IgnoreFile), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{path: "api/config/.env", expected: "api/.gitignore"},
+		{path: "api/.env", expected: "api/.gitignore"},
+		{path: "web/.env", expected: ".gitignore"},
+		{path: ".env", expected: ".gitignore"},
+	}
+
+	for _, c := range cases {
+		f, err := NearestIgnoreFile(filepath.Join(root, c.path), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if f != filepath.Join(root, c.expected) {
+			t.Errorf("%s: expected %s, got %s", c.path, c.expected, f)
+		}
+	}
+}
+
+func TestIgnorePattern(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ignoreFile := filepath.Join(root, IgnoreFile)
+
+	cases := []struct {
+		path     string
+		expected string
+	}{
+		{path: "config/.env", expected: "/config/.env"},
+		{path: "config/dev/app.json", expected: "/config/dev/app.json"},
+		{path: "config/a*b?.env", expected: `/config/a\*b\?.env`},
+		{path: "#notes[1].txt", expected: `/\#notes\[1].txt`},
+		{path: "!important.env", expected: `/\!important.env`},
+	}
+
+	for _, c := range cases {
+		p, err := IgnorePattern(ignoreFile, filepath.Join(root, c.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.path, c.expected, p)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// ECSKeyValue is a plain text container environment variable.
+type ECSKeyValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ECSSecret is a container environment variable injected from
+// Secrets Manager or Parameter Store.
+type ECSSecret struct {
+	Name      string `json:"name"`
+	ValueFrom string `json:"valueFrom"`
+}
+
+// ECSEnvironmentFile is an S3 object loaded into the container
+// environment.
+type ECSEnvironmentFile struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// ECSContainerDefinition holds the configuration fields of an ECS
+// container definition.
+type ECSContainerDefinition struct {
+	Environment      []ECSKeyValue        `json:"environment,omitempty"`
+	Secrets          []ECSSecret          `json:"secrets,omitempty"`
+	EnvironmentFiles []ECSEnvironmentFile `json:"environmentFiles,omitempty"`
+}
+
+// Merge adds the configuration from another container definition.
+// Variables with the same name are replaced.
+func (d *ECSContainerDefinition) Merge(o ECSContainerDefinition) {
+	for _, e := range o.Environment {
+		d.Environment = append(removeEnvironment(d.Environment, e.Name), e)
+	}
+
+	for _, s := range o.Secrets {
+		d.Secrets = append(removeSecret(d.Secrets, s.Name), s)
+	}
+
+	for _, f := range o.EnvironmentFiles {
+		d.EnvironmentFiles = append(removeEnvironmentFile(d.EnvironmentFiles, f.Value), f)
+	}
+
+	// A name is either plain text or a secret; the latest wins.
+	for _, s := range o.Secrets {
+		d.Environment = removeEnvironment(d.Environment, s.Name)
+	}
+
+	for _, e := range o.Environment {
+		d.Secrets = removeSecret(d.Secrets, e.Name)
+	}
+
+	d.sort()
+}
+
+// RemoveRefs removes the secrets and environment files referencing
+// ARNs selected by owned. Plain text variables do not reference an
+// ARN and are kept.
+func (d *ECSContainerDefinition) RemoveRefs(owned func(arn string) bool) {
+	secrets := []ECSSecret{}
+	for _, s := range d.Secrets {
+		if !owned(s.ValueFrom) {
+			secrets = append(secrets, s)
+		}
+	}
+
+	files := []ECSEnvironmentFile{}
+	for _, f := range d.EnvironmentFiles {
+		if !owned(f.Value) {
+			files = append(files, f)
+		}
+	}
+
+	d.Secrets = secrets
+	d.EnvironmentFiles = files
+}
+
+// RemoveEnvironment removes the plain text variables named.
+func (d *ECSContainerDefinition) RemoveEnvironment(names []string) {
+	for _, n := range names {
+		d.Environment = removeEnvironment(d.Environment, n)
+	}
+}
+
+// Marshal formats the container definition as indented JSON.
+func (d ECSContainerDefinition) Marshal() ([]byte, error) {
+	d.sort()
+
+	var b bytes.Buffer
+
+	// Values are written as is; ECS does not require HTML escaping.
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	if err := enc.Encode(d); err != nil {
+		return []byte{}, err
+	}
+
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
+
+func (d *ECSContainerDefinition) sort() {
+	sort.SliceStable(d.Environment, func(i, j int) bool { return d.Environment[i].Name < d.Environment[j].Name })
+	sort.SliceStable(d.Secrets, func(i, j int) bool { return d.Secrets[i].Name < d.Secrets[j].Name })
+	sort.SliceStable(d.EnvironmentFiles, func(i, j int) bool { return d.EnvironmentFiles[i].Value < d.EnvironmentFiles[j].Value })
+}
+
+func removeEnvironment(env []ECSKeyValue, name string) []ECSKeyValue {
+	out := []ECSKeyValue{}
+	for _, e := range env {
+		if e.Name != name {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
+
+func removeSecret(secrets []ECSSecret, name string) []ECSSecret {
+	out := []ECSSecret{}
+	for _, s := range secrets {
+		if s.Name != name {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+func removeEnvironmentFile(files []ECSEnvironmentFile, value string) []ECSEnvironmentFile {
+	out := []ECSEnvironmentFile{}
+	for _, f := range files {
+		if f.Value != value {
+			out = append(out, f)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	k8sExternalSecretAPIVersion = "external-secrets.io/v1beta1"
+	k8sRefreshInterval          = "1h"
+)
+
+// SecretStore is the External Secrets Operator store used to read
+// remote data.
+type SecretStore struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+}
+
+// ExternalSecretRef maps a remote key to the Kubernetes secret. When
+// Extract is set, every property in the remote JSON becomes a secret
+// key and SecretKey is ignored.
+type ExternalSecretRef struct {
+	SecretKey string
+	Key       string
+	Property  string
+	Extract   bool
+}
+
+type k8sMetadata struct {
+	Name string `yaml:"name"`
+}
+
+type k8sRemoteRef struct {
+	Key      string `yaml:"key"`
+	Property string `yaml:"property,omitempty"`
+}
+
+type k8sData struct {
+	SecretKey string       `yaml:"secretKey"`
+	RemoteRef k8sRemoteRef `yaml:"remoteRef"`
+}
+
+type k8sDataFrom struct {
+	Extract k8sRemoteRef `yaml:"extract"`
+}
+
+type k8sTarget struct {
+	Name string `yaml:"name"`
+}
+
+type k8sExternalSecretSpec struct {
+	RefreshInterval string        `yaml:"refreshInterval"`
+	SecretStoreRef  SecretStore   `yaml:"secretStoreRef"`
+	Target          k8sTarget     `yaml:"target"`
+	Data            []k8sData     `yaml:"data,omitempty"`
+	DataFrom        []k8sDataFrom `yaml:"dataFrom,omitempty"`
+}
+
+type k8sExternalSecret struct {
+	APIVersion string                `yaml:"apiVersion"`
+	Kind       string                `yaml:"kind"`
+	Metadata   k8sMetadata           `yaml:"metadata"`
+	Spec       k8sExternalSecretSpec `yaml:"spec"`
+}
+
+// ExternalSecret renders an External Secrets Operator manifest
+// referencing the remote keys instead of values.
+func ExternalSecret(name string, store SecretStore, refs []ExternalSecretRef) ([]byte, error) {
+	spec := k8sExternalSecretSpec{
+		RefreshInterval: k8sRefreshInterval,
+		SecretStoreRef:  store,
+		Target:          k8sTarget{Name: name},
+	}
+
+	for _, r := range refs {
+		if r.Extract {
+			spec.DataFrom = append(spec.DataFrom, k8sDataFrom{
+				Extract: k8sRemoteRef{Key: r.Key},
+			})
+			continue
+		}
+
+		spec.Data = append(spec.Data, k8sData{
+			SecretKey: r.SecretKey,
+			RemoteRef: k8sRemoteRef{
+				Key:      r.Key,
+				Property: r.Property,
+			},
+		})
+	}
+
+	return k8sManifest(k8sExternalSecret{
+		APIVersion: k8sExternalSecretAPIVersion,
+		Kind:       "ExternalSecret",
+		Metadata:   k8sMetadata{Name: name},
+		Spec:       spec,
+	})
+}
+
+// KubernetesName converts a local file path into a valid Kubernetes
+// resource name.
+func KubernetesName(path string) string {
+	name := strings.ToLower(filepath.ToSlash(filepath.Clean(path)))
+
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(name, "-"), "-")
+
+	if len(name) > 253 {
+		name = strings.Trim(name[:253], "-")
+	}
+
+	return name
+}
+
+// KubernetesKey returns the secret key used when a whole file is
+// stored in a single Kubernetes secret or config map key.
+func KubernetesKey(path string) string {
+	return regexp.MustCompile(`[^-._a-zA-Z0-9]+`).ReplaceAllString(filepath.Base(path), "_")
+}
+
+// k8sManifest marshals a manifest as a separate YAML document so
+// multiple files can be applied together.
+func k8sManifest(v interface{}) ([]byte, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return b, err
+	}
+
+	return append([]byte("---\n"), b...), nil
+}
@@ -0,0 +1,278 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/dabblebox/stash/component/dotenv"
+	"github.com/dabblebox/stash/component/file"
+	"github.com/dabblebox/stash/component/properties"
+	"gopkg.in/yaml.v2"
+)
+
+// FlattenDelimiter joins nested JSON and YAML keys.
+// (e.g. {"db":{"host":"localhost"}} => db_host=localhost)
+const FlattenDelimiter = "_"
+
+// parseFields parses the file into the common key/value model used by
+// every transformer. Nested JSON and YAML values are flattened.
+func parseFields(fileType string, data []byte) (map[string]string, error) {
+	fields := map[string]string{}
+
+	values, err := parseValues(fileType, data)
+	if err != nil {
+		return fields, err
+	}
+
+	for k, v := range values {
+		if v == nil {
+			fields[k] = ""
+			continue
+		}
+
+		fields[k] = fmt.Sprintf("%v", v)
+	}
+
+	return fields, nil
+}
+
+// parseValues parses the file into flattened keys keeping the JSON and
+// YAML value types; so, 1 and "1" can be told apart.
+func parseValues(fileType string, data []byte) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	switch fileType {
+	case file.TypeEnv, file.TypeProperties:
+		var fields map[string]string
+		var err error
+
+		if fileType == file.TypeEnv {
+			fields, err = dotenv.Parse(bytes.NewReader(data))
+		} else {
+			fields, err = properties.Parse(bytes.NewReader(data))
+		}
+		if err != nil {
+			return values, err
+		}
+
+		for k, v := range fields {
+			values[k] = v
+		}
+
+		return values, nil
+	case file.TypeJSON:
+		var v interface{}
+
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+
+		if err := d.Decode(&v); err != nil {
+			return values, err
+		}
+
+		return values, flatten("", v, values)
+	case file.TypeYAML, file.TypeYML:
+		var v interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return values, err
+		}
+
+		return values, flatten("", v, values)
+	}
+
+	return values, fmt.Errorf("transformer does not support %s files", fileType)
+}
+
+// flatten adds each scalar value to the values joining nested keys and
+// list indexes with the delimiter.
+func flatten(prefix string, v interface{}, values map[string]interface{}) error {
+	join := func(k string) string {
+		if len(prefix) == 0 {
+			return k
+		}
+
+		return prefix + FlattenDelimiter + k
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if err := flatten(join(k), child, values); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, child := range t {
+			if err := flatten(join(fmt.Sprintf("%v", k)), child, values); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, child := range t {
+			if err := flatten(join(strconv.Itoa(i)), child, values); err != nil {
+				return err
+			}
+		}
+	case nil:
+		if len(prefix) > 0 {
+			values[prefix] = nil
+		}
+	default:
+		if len(prefix) == 0 {
+			return fmt.Errorf("object required, found %v", t)
+		}
+
+		values[prefix] = t
+	}
+
+	return nil
+}
+
+// invalidEnvChars are the characters not allowed in variable names.
+var invalidEnvChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// envFields renames the fields to valid shell variable names replacing
+// invalid characters with the delimiter. (e.g. db.host => db_host)
+func envFields(fields map[string]string) (map[string]string, error) {
+	env := map[string]string{}
+	names := map[string]string{}
+
+	for _, k := range sortedNames(fields) {
+		name := invalidEnvChars.ReplaceAllString(k, FlattenDelimiter)
+		if len(name) == 0 || (name[0] >= '0' && name[0] <= '9') {
+			name = FlattenDelimiter + name
+		}
+
+		if other, found := names[name]; found {
+			return env, fmt.Errorf("keys %s and %s are both named %s", other, k, name)
+		}
+		names[name] = k
+
+		env[name] = fields[k]
+	}
+
+	return env, nil
+}
+
+// parsable determines if the file type is supported by the model.
+func parsable(fileType string) bool {
+	switch fileType {
+	case file.TypeEnv, file.TypeProperties, file.TypeJSON, file.TypeYAML, file.TypeYML:
+		return true
+	}
+
+	return false
+}
+
+// Equal compares file data. Parsable files are equal when their
+// key/value pairs and value types match; so, formatting and ordering
+// are ignored but 1 and "1" differ.
+func Equal(fileType string, a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	if !parsable(fileType) {
+		return false
+	}
+
+	fa, err := parseValues(fileType, a)
+	if err != nil {
+		return false
+	}
+
+	fb, err := parseValues(fileType, b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(fa, fb)
+}
+
+// Changes lists the keys added (+), removed (-), and changed (~) from
+// one parsable file to another. Values are not listed; so, secrets
+// are not written to the terminal.
+func Changes(fileType string, from, to []byte) ([]string, error) {
+	changes := []string{}
+
+	if !parsable(fileType) {
+		return changes, fmt.Errorf("%s files are not compared by key", fileType)
+	}
+
+	ff, err := parseValues(fileType, from)
+	if err != nil {
+		return changes, err
+	}
+
+	ft, err := parseValues(fileType, to)
+	if err != nil {
+		return changes, err
+	}
+
+	for _, k := range sortedValueNames(ft) {
+		v, found := ff[k]
+
+		switch {
+		case !found:
+			changes = append(changes, "+ "+k)
+		case !reflect.DeepEqual(v, ft[k]):
+			changes = append(changes, "~ "+k)
+		}
+	}
+
+	for _, k := range sortedValueNames(ff) {
+		if _, found := ft[k]; !found {
+			changes = append(changes, "- "+k)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i][2:] < changes[j][2:] })
+
+	return changes, nil
+}
+
+// typedValue converts boolean and integer strings for structured
+// outputs.
+func typedValue(v string) interface{} {
+	switch v {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return json.Number(v)
+	}
+
+	return v
+}
+
+// sortedNames returns the field names in a stable order.
+func sortedNames(fields map[string]string) []string {
+	names := []string{}
+	for k := range fields {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// sortedValueNames returns the typed value names in a stable order.
+func sortedValueNames(values map[string]interface{}) []string {
+	names := []string{}
+	for k := range values {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
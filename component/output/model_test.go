@@ -0,0 +1,71 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		a        string
+		b        string
+		expected bool
+	}{
+		{name: "env order", fileType: file.TypeEnv, a: "A=1\nB=2\n", b: "B=2\nA=1\n", expected: true},
+		{name: "env value", fileType: file.TypeEnv, a: "A=1\n", b: "A=2\n", expected: false},
+		{name: "json format", fileType: file.TypeJSON, a: `{"a":1,"b":{"c":true}}`, b: "{\n  \"b\": {\"c\": true},\n  \"a\": 1\n}", expected: true},
+		{name: "json number", fileType: file.TypeJSON, a: `{"a":1}`, b: `{"a":"1"}`, expected: false},
+		{name: "json bool", fileType: file.TypeJSON, a: `{"a":true}`, b: `{"a":"true"}`, expected: false},
+		{name: "json null", fileType: file.TypeJSON, a: `{"a":null}`, b: `{"a":""}`, expected: false},
+		{name: "yaml format", fileType: file.TypeYAML, a: "a: 1\nb: [x, y]\n", b: "b:\n  - x\n  - y\na: 1\n", expected: true},
+		{name: "yaml number", fileType: file.TypeYAML, a: "a: 1\n", b: "a: \"1\"\n", expected: false},
+		{name: "yaml bool", fileType: file.TypeYML, a: "a: true\n", b: "a: \"true\"\n", expected: false},
+		{name: "unparsable", fileType: file.TypeJSON, a: `{"a":1}`, b: `{"a":`, expected: false},
+		{name: "raw", fileType: "pem", a: "key", b: "key", expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := Equal(c.fileType, []byte(c.a), []byte(c.b)); actual != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		from     string
+		to       string
+		expected []string
+	}{
+		{name: "env", fileType: file.TypeEnv, from: "A=1\nB=2\n", to: "A=1\nB=3\nC=4\n", expected: []string{"~ B", "+ C"}},
+		{name: "removed", fileType: file.TypeEnv, from: "A=1\nB=2\n", to: "A=1\n", expected: []string{"- B"}},
+		{name: "json type", fileType: file.TypeJSON, from: `{"a":1,"b":{"c":true}}`, to: `{"a":"1","b":{"c":true}}`, expected: []string{"~ a"}},
+		{name: "yaml nested", fileType: file.TypeYAML, from: "db:\n  host: a\n", to: "db:\n  host: b\n  port: 5432\n", expected: []string{"~ db_host", "+ db_port"}},
+		{name: "none", fileType: file.TypeJSON, from: `{"a":1}`, to: `{ "a": 1 }`, expected: []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := Changes(c.fileType, []byte(c.from), []byte(c.to))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+
+	if _, err := Changes("pem", []byte("a"), []byte("b")); err == nil {
+		t.Error("expected an error for files not compared by key")
+	}
+}
@@ -1,15 +1,34 @@
 package output
 
 const (
-	TypeTerraform         = "terraform"
-	TypeECSTaskEnv        = "ecs-task-env"
-	TypeECSTaskInjectJson = "ecs-task-inject-json"
-	TypeECSTaskInjectEnv  = "ecs-task-inject-env"
-	TypeJSONObject        = "json"
-	TypeExport            = "terminal-export"
-	TypeExportLiteral     = "terminal-export-literal"
-	TypeOriginal          = "original"
-	TypeFile              = "file"
+	TypeTerraform          = "terraform"
+	TypeTerraformResources = "terraform-resources"
+	TypeCloudFormation     = "cloudformation"
+	TypePulumi             = "pulumi"
+	TypeIAMPolicy          = "iam-policy"
+	TypeK8sSecret          = "k8s-secret"
+	TypeK8sSecretFile      = "k8s-secret-file"
+	TypeK8sConfigMap       = "k8s-configmap"
+	TypeK8sConfigMapFile   = "k8s-configmap-file"
+	TypeK8sExternalSecret  = "k8s-external-secret"
+	TypeECSTaskEnv         = "ecs-task-env"
+	TypeECSTaskInjectJson  = "ecs-task-inject-json"
+	TypeECSTaskInjectEnv   = "ecs-task-inject-env"
+	TypeECSContainerDef    = "ecs-container-def"
+	TypeComposeEnv         = "compose-env"
+	TypeComposeEnvFile     = "compose-env-file"
+	TypeSystemd            = "systemd"
+	TypeSystemdEnvFile     = "systemd-env-file"
+	TypeGitHubEnv          = "github-env"
+	TypeGitHubOutput       = "github-output"
+	TypeGitLabDotenv       = "gitlab-dotenv"
+	TypeJSONObject         = "json"
+	TypeEnv                = "env"
+	TypeYAML               = "yaml"
+	TypeExport             = "terminal-export"
+	TypeExportLiteral      = "terminal-export-literal"
+	TypeOriginal           = "original"
+	TypeFile               = "file"
 )
 
 type ITransformer interface {
@@ -17,22 +36,62 @@ type ITransformer interface {
 }
 
 // GetTransformer ...
-func GetTransformer(output, fileType string) (ITransformer, error) {
+func GetTransformer(output, fileType, path string) (ITransformer, error) {
+
+	if tmpl, ok := TemplatePath(output); ok {
+		return TemplateTransformer{
+			Template: tmpl,
+			File:     TemplateFile{Path: path, Type: fileType},
+		}, nil
+	}
 
 	switch output {
 	case TypeJSONObject:
 		return JSONTransformer{
 			fileType: fileType,
 		}, nil
+	case TypeEnv:
+		return EnvTransformer{
+			fileType: fileType,
+		}, nil
+	case TypeYAML:
+		return YAMLTransformer{
+			fileType: fileType,
+		}, nil
 	case TypeExport:
 		return ExportTransformer{
 			fileType: fileType,
-			literal: false,
+			literal:  false,
 		}, nil
 	case TypeExportLiteral:
 		return ExportTransformer{
 			fileType: fileType,
-			literal: true,
+			literal:  true,
+		}, nil
+	case TypeK8sSecret, TypeK8sSecretFile, TypeK8sConfigMap, TypeK8sConfigMapFile:
+		return KubernetesTransformer{
+			fileType: fileType,
+			path:     path,
+			secret:   output == TypeK8sSecret || output == TypeK8sSecretFile,
+			whole:    output == TypeK8sSecretFile || output == TypeK8sConfigMapFile,
+		}, nil
+	case TypeComposeEnv, TypeComposeEnvFile:
+		return ComposeTransformer{
+			fileType: fileType,
+			envFile:  output == TypeComposeEnvFile,
+		}, nil
+	case TypeSystemd, TypeSystemdEnvFile:
+		return SystemdTransformer{
+			fileType: fileType,
+			envFile:  output == TypeSystemdEnvFile,
+		}, nil
+	case TypeGitHubEnv, TypeGitHubOutput:
+		return GitHubTransformer{
+			fileType: fileType,
+		}, nil
+	case TypeGitLabDotenv:
+		return GitLabTransformer{
+			fileType: fileType,
 		}, nil
 	case TypeECSTaskEnv:
 		return TaskDefEnvTransformer{
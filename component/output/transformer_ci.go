@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const gitHubMask = "::add-mask::"
+
+// GitHubTransformer renders an env or JSON file as GitHub Actions
+// workflow commands. Values are masked first and then written using
+// the multiline file command syntax.
+type GitHubTransformer struct {
+	fileType string
+}
+
+func (t GitHubTransformer) Transform(data []byte) ([]byte, error) {
+	fields, err := parseFields(t.fileType, data)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	fields, err = envFields(fields)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var masks, commands bytes.Buffer
+
+	for _, k := range sortedNames(fields) {
+		v := fields[k]
+
+		// Each line of a multiline value is masked separately.
+		for _, line := range strings.Split(v, "\n") {
+			if len(strings.TrimSpace(line)) > 0 {
+				masks.WriteString(fmt.Sprintf("%s%s\n", gitHubMask, line))
+			}
+		}
+
+		delimiter, err := gitHubDelimiter(v)
+		if err != nil {
+			return []byte{}, err
+		}
+
+		commands.WriteString(fmt.Sprintf("%s<<%s\n%s\n%s\n", k, delimiter, v, delimiter))
+	}
+
+	return append(masks.Bytes(), commands.Bytes()...), nil
+}
+
+// GitHubFileVariable returns the environment variable holding the
+// runner file for the output.
+func GitHubFileVariable(output string) string {
+	if output == TypeGitHubOutput {
+		return "GITHUB_OUTPUT"
+	}
+
+	return "GITHUB_ENV"
+}
+
+// SplitGitHubCommands separates the mask commands, which the runner
+// reads from stdout, from the commands appended to the runner file.
+func SplitGitHubCommands(data []byte) ([]byte, []byte) {
+	offset := 0
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte(gitHubMask)) {
+			break
+		}
+
+		offset += len(line)
+	}
+
+	return data[:offset], data[offset:]
+}
+
+// gitHubDelimiter returns a random heredoc delimiter that does not
+// occur in the value.
+func gitHubDelimiter(value string) (string, error) {
+	for {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+
+		d := fmt.Sprintf("ghadelimiter_%s", hex.EncodeToString(b))
+		if !strings.Contains(value, d) {
+			return d, nil
+		}
+	}
+}
+
+// GitLabTransformer renders an env or JSON file as a GitLab CI
+// dotenv report artifact.
+type GitLabTransformer struct {
+	fileType string
+}
+
+func (t GitLabTransformer) Transform(data []byte) ([]byte, error) {
+	fields, err := parseFields(t.fileType, data)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	fields, err = envFields(fields)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var b bytes.Buffer
+	for _, k := range sortedNames(fields) {
+		if strings.Contains(fields[k], "\n") {
+			return []byte{}, fmt.Errorf("%s: dotenv reports do not support multiline values", k)
+		}
+
+		b.WriteString(fmt.Sprintf("%s=%s\n", k, fields[k]))
+	}
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestGitHubTransformer(t *testing.T) {
+	cases := []struct {
+		name          string
+		fileType      string
+		data          string
+		expectedMasks string
+		expected      string
+	}{
+		{
+			name:          "env",
+			fileType:      file.TypeEnv,
+			data:          "B=2\nA=1\n",
+			expectedMasks: "::add-mask::1\n::add-mask::2\n",
+			expected:      "A<<D\n1\nD\nB<<D\n2\nD\n",
+		},
+		{
+			name:          "multiline",
+			fileType:      file.TypeJSON,
+			data:          `{"key":"line 1\n\nline 2"}`,
+			expectedMasks: "::add-mask::line 1\n::add-mask::line 2\n",
+			expected:      "key<<D\nline 1\n\nline 2\nD\n",
+		},
+		{
+			name:          "variable names",
+			fileType:      file.TypeYAML,
+			data:          "db:\n  host: localhost\n",
+			expectedMasks: "::add-mask::localhost\n",
+			expected:      "db_host<<D\nlocalhost\nD\n",
+		},
+	}
+
+	delimiter := regexp.MustCompile(`ghadelimiter_[0-9a-f]{16}`)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := GitHubTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			masks, commands := SplitGitHubCommands(actual)
+
+			if string(masks) != c.expectedMasks {
+				t.Errorf("expected masks:\n%s\ngot:\n%s", c.expectedMasks, masks)
+			}
+
+			if actual := delimiter.ReplaceAllString(string(commands), "D"); actual != c.expected {
+				t.Errorf("expected commands:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGitHubFileVariable(t *testing.T) {
+	cases := []struct {
+		output   string
+		expected string
+	}{
+		{output: TypeGitHubEnv, expected: "GITHUB_ENV"},
+		{output: TypeGitHubOutput, expected: "GITHUB_OUTPUT"},
+	}
+
+	for _, c := range cases {
+		if actual := GitHubFileVariable(c.output); actual != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.output, c.expected, actual)
+		}
+	}
+}
+
+func TestGitLabTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		data     string
+		expected string
+		err      bool
+	}{
+		{name: "env", fileType: file.TypeEnv, data: "B=2\nA=1\n", expected: "A=1\nB=2\n"},
+		{name: "variable names", fileType: file.TypeProperties, data: "db.host=localhost\n", expected: "db_host=localhost\n"},
+		{name: "multiline", fileType: file.TypeJSON, data: `{"key":"a\nb"}`, err: true},
+		{name: "collision", fileType: file.TypeProperties, data: "db.host=a\ndb_host=b\n", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := GitLabTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if c.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ComposeTransformer renders an env or JSON file as a docker-compose
+// service environment block or env_file.
+type ComposeTransformer struct {
+	fileType string
+	envFile  bool
+}
+
+func (t ComposeTransformer) Transform(data []byte) ([]byte, error) {
+	fields, err := parseFields(t.fileType, data)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	fields, err = envFields(fields)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	if t.envFile {
+		var b bytes.Buffer
+		for _, k := range sortedNames(fields) {
+			b.WriteString(fmt.Sprintf("%s=%s\n", k, composeEnvFileValue(fields[k])))
+		}
+
+		return b.Bytes(), nil
+	}
+
+	env := yaml.MapSlice{}
+	for _, k := range sortedNames(fields) {
+		// Compose interpolates variables in the compose file.
+		env = append(env, yaml.MapItem{Key: k, Value: strings.Replace(fields[k], "$", "$$", -1)})
+	}
+
+	return yaml.Marshal(yaml.MapSlice{{Key: "environment", Value: env}})
+}
+
+// composeEnvFileValue quotes values that would otherwise be changed
+// or interpolated when compose reads the env file. Single quoted
+// values are literal.
+func composeEnvFileValue(v string) string {
+	if !regexp.MustCompile(`[\s#"'\\$]`).MatchString(v) {
+		return v
+	}
+
+	if !strings.ContainsAny(v, "'\n") {
+		return fmt.Sprintf("'%s'", v)
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+
+	return fmt.Sprintf(`"%s"`, r.Replace(v))
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestComposeTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		envFile  bool
+		data     string
+		expected string
+	}{
+		{
+			name:     "environment",
+			fileType: file.TypeEnv,
+			data:     "B=2\nA=$HOME\n",
+			expected: "environment:\n  A: $$HOME\n  B: \"2\"\n",
+		},
+		{
+			name:     "nested json",
+			fileType: file.TypeJSON,
+			data:     `{"db":{"host":"localhost","port":5432}}`,
+			expected: "environment:\n  db_host: localhost\n  db_port: \"5432\"\n",
+		},
+		{
+			name:     "env file",
+			fileType: file.TypeEnv,
+			data:     "PLAIN=value\nSPACE=\"a b\"\nQUOTE=\"it's\"\nDOLLAR=$HOME\n",
+			envFile:  true,
+			expected: "DOLLAR='$HOME'\nPLAIN=value\nQUOTE=\"it's\"\nSPACE='a b'\n",
+		},
+		{
+			name:     "variable names",
+			fileType: file.TypeProperties,
+			data:     "db.host=localhost\n",
+			envFile:  true,
+			expected: "db_host=localhost\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ComposeTransformer{fileType: c.fileType, envFile: c.envFile}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestComposeTransformerNameCollision(t *testing.T) {
+	_, err := ComposeTransformer{fileType: file.TypeProperties}.Transform([]byte("db.host=a\ndb_host=b\n"))
+	if err == nil {
+		t.Error("expected an error for keys with the same variable name")
+	}
+}
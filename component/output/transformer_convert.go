@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvTransformer converts any parsable file into an env file.
+type EnvTransformer struct {
+	fileType string
+}
+
+func (t EnvTransformer) Transform(data []byte) ([]byte, error) {
+	params, err := parseFields(t.fileType, data)
+	if err != nil {
+		return data, err
+	}
+
+	params, err = envFields(params)
+	if err != nil {
+		return data, err
+	}
+
+	var b bytes.Buffer
+	for _, k := range sortedNames(params) {
+		if _, ok := typedValue(params[k]).(string); !ok {
+			b.WriteString(fmt.Sprintf("%s=%s\n", k, params[k]))
+			continue
+		}
+
+		// New lines are the only escapes read by the env parser.
+		b.WriteString(fmt.Sprintf("%s=\"%s\"\n", k, strings.Replace(params[k], "\n", `\n`, -1)))
+	}
+
+	return b.Bytes(), nil
+}
+
+// YAMLTransformer converts any parsable file into a flat YAML
+// mapping.
+type YAMLTransformer struct {
+	fileType string
+}
+
+func (t YAMLTransformer) Transform(data []byte) ([]byte, error) {
+	params, err := parseFields(t.fileType, data)
+	if err != nil {
+		return data, err
+	}
+
+	m := yaml.MapSlice{}
+	for _, k := range sortedNames(params) {
+		m = append(m, yaml.MapItem{Key: k, Value: yamlValue(params[k])})
+	}
+
+	return yaml.Marshal(m)
+}
+
+// yamlValue keeps booleans and integers unquoted.
+func yamlValue(v string) interface{} {
+	t := typedValue(v)
+
+	if n, ok := t.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+	}
+
+	return t
+}
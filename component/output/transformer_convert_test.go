@@ -0,0 +1,59 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestEnvTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		data     string
+		expected string
+	}{
+		{name: "typed", fileType: file.TypeJSON, data: `{"n":1,"b":true,"s":"text"}`, expected: "b=true\nn=1\ns=\"text\"\n"},
+		{name: "multiline", fileType: file.TypeJSON, data: `{"s":"a\nb"}`, expected: "s=\"a\\nb\"\n"},
+		{name: "variable names", fileType: file.TypeYAML, data: "db:\n  host.name: localhost\n", expected: "db_host_name=\"localhost\"\n"},
+		{name: "properties", fileType: file.TypeProperties, data: "app.port=8080\n", expected: "app_port=8080\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := EnvTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestYAMLTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		data     string
+		expected string
+	}{
+		{name: "env", fileType: file.TypeEnv, data: "B=true\nA=1\nC=text\n", expected: "A: 1\nB: true\nC: text\n"},
+		{name: "nested json", fileType: file.TypeJSON, data: `{"db":{"hosts":["a","b"]}}`, expected: "db_hosts_0: a\ndb_hosts_1: b\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := YAMLTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
@@ -3,22 +3,20 @@ package output
 import (
 	"bytes"
 	"fmt"
-	
-	"github.com/dabblebox/stash/component/dotenv"
-	"github.com/dabblebox/stash/component/file"
 )
 
 type ExportTransformer struct {
 	fileType string
-	literal bool
+	literal  bool
 }
 
 func (t ExportTransformer) Transform(data []byte) ([]byte, error) {
-	if t.fileType != file.TypeEnv {
-		return []byte{}, fmt.Errorf("transformer does not support %s files", t.fileType)
+	params, err := parseFields(t.fileType, data)
+	if err != nil {
+		return data, err
 	}
 
-	params, err := dotenv.Parse(bytes.NewReader(data))
+	params, err = envFields(params)
 	if err != nil {
 		return data, err
 	}
@@ -26,8 +24,8 @@ func (t ExportTransformer) Transform(data []byte) ([]byte, error) {
 	quote := getQuote(t.literal)
 
 	var b bytes.Buffer
-	for k, v := range params {
-		b.WriteString(fmt.Sprintf("export %s=%s%s%s\n", k, quote, v, quote))
+	for _, k := range sortedNames(params) {
+		b.WriteString(fmt.Sprintf("export %s=%s%s%s\n", k, quote, params[k], quote))
 	}
 
 	return b.Bytes(), nil
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestExportTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		literal  bool
+		data     string
+		expected string
+		err      bool
+	}{
+		{name: "double quotes", fileType: file.TypeEnv, data: "B=2\nA=1\n", expected: "export A=\"1\"\nexport B=\"2\"\n"},
+		{name: "single quotes", fileType: file.TypeEnv, literal: true, data: "A=$HOME\n", expected: "export A='$HOME'\n"},
+		{name: "variable names", fileType: file.TypeJSON, data: `{"db":{"host-name":"localhost"},"1st":"x"}`, expected: "export _1st=\"x\"\nexport db_host_name=\"localhost\"\n"},
+		{name: "collision", fileType: file.TypeProperties, data: "db.host=a\ndb_host=b\n", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ExportTransformer{fileType: c.fileType, literal: c.literal}.Transform([]byte(c.data))
+			if c.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
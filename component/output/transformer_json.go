@@ -1,12 +1,9 @@
 package output
 
 import (
-	"bytes"
 	"encoding/json"
-	"fmt"
 	"strconv"
 
-	"github.com/dabblebox/stash/component/dotenv"
 	"github.com/dabblebox/stash/component/file"
 )
 
@@ -19,30 +16,14 @@ func (t JSONTransformer) Transform(data []byte) ([]byte, error) {
 		return data, nil
 	}
 
-	if t.fileType != file.TypeEnv {
-		return []byte{}, fmt.Errorf("transformer does not support %s files", t.fileType)
-	}
-
-	params, err := dotenv.Parse(bytes.NewReader(data))
+	params, err := parseFields(t.fileType, data)
 	if err != nil {
 		return data, err
 	}
 
 	m := map[string]interface{}{}
 	for k, v := range params {
-
-		var value interface{}
-		if b, err := strconv.ParseBool(v); err == nil {
-			value = b
-		} else if _, err := strconv.ParseInt(v, 10, 64); err == nil {
-			value = json.Number(v)
-		} else if _, err := strconv.ParseFloat(v, 64); err == nil {
-			value = v
-		} else {
-			value = v
-		}
-
-		m[k] = value
+		m[k] = jsonValue(v)
 	}
 
 	b, err := json.MarshalIndent(m, "", "    ")
@@ -52,3 +33,13 @@ func (t JSONTransformer) Transform(data []byte) ([]byte, error) {
 
 	return b, nil
 }
+
+// jsonValue converts any value accepted by strconv.ParseBool to a
+// boolean (e.g. 1, t, TRUE) and integers to numbers.
+func jsonValue(v string) interface{} {
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b
+	}
+
+	return typedValue(v)
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestJSONTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		data     string
+		expected string
+	}{
+		{
+			name:     "json",
+			fileType: file.TypeJSON,
+			data:     `{"a":"1"}`,
+			expected: `{"a":"1"}`,
+		},
+		{
+			name:     "bools",
+			fileType: file.TypeEnv,
+			data:     "A=1\nB=t\nC=FALSE\nD=yes\n",
+			expected: "{\n    \"A\": true,\n    \"B\": true,\n    \"C\": false,\n    \"D\": \"yes\"\n}",
+		},
+		{
+			name:     "numbers",
+			fileType: file.TypeProperties,
+			data:     "port=8080\nratio=1.5\n",
+			expected: "{\n    \"port\": 8080,\n    \"ratio\": \"1.5\"\n}",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := JSONTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
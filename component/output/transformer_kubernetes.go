@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/base64"
+)
+
+type k8sSecret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// KubernetesTransformer renders a Secret or ConfigMap manifest.
+// Parsable files are split into one key per field unless the whole
+// file is requested.
+type KubernetesTransformer struct {
+	fileType string
+	path     string
+	secret   bool
+	whole    bool
+}
+
+func (t KubernetesTransformer) Transform(data []byte) ([]byte, error) {
+	fields := map[string]string{}
+
+	switch {
+	case t.whole:
+		fields[KubernetesKey(t.path)] = string(data)
+	case parsable(t.fileType):
+		f, err := parseFields(t.fileType, data)
+		if err != nil {
+			return data, err
+		}
+
+		fields = f
+	default:
+		fields[KubernetesKey(t.path)] = string(data)
+	}
+
+	m := k8sSecret{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   k8sMetadata{Name: KubernetesName(t.path)},
+		Data:       fields,
+	}
+
+	if t.secret {
+		m.Kind = "Secret"
+		m.Type = "Opaque"
+
+		m.Data = map[string]string{}
+		for k, v := range fields {
+			m.Data[k] = base64.StdEncoding.EncodeToString([]byte(v))
+		}
+	}
+
+	return k8sManifest(m)
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SystemdTransformer renders an env or JSON file as a systemd unit
+// drop-in with Environment= lines or as an EnvironmentFile.
+type SystemdTransformer struct {
+	fileType string
+	envFile  bool
+}
+
+func (t SystemdTransformer) Transform(data []byte) ([]byte, error) {
+	fields, err := parseFields(t.fileType, data)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	fields, err = envFields(fields)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var b bytes.Buffer
+
+	if t.envFile {
+		for _, k := range sortedNames(fields) {
+			b.WriteString(fmt.Sprintf("%s=%s\n", k, systemdEnvFileValue(fields[k])))
+		}
+
+		return b.Bytes(), nil
+	}
+
+	b.WriteString("[Service]\n")
+	for _, k := range sortedNames(fields) {
+		b.WriteString(fmt.Sprintf("Environment=%s\n", systemdEnvironment(k, fields[k])))
+	}
+
+	return b.Bytes(), nil
+}
+
+// systemdEnvironment quotes the assignment escaping specifiers and
+// characters systemd treats as C-style escapes.
+func systemdEnvironment(k, v string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "%", "%%")
+
+	return fmt.Sprintf(`"%s=%s"`, k, r.Replace(v))
+}
+
+// systemdEnvFileValue double quotes values containing whitespace,
+// quotes, or backslashes.
+func systemdEnvFileValue(v string) string {
+	if !regexp.MustCompile(`[\s#;"'\\]`).MatchString(v) {
+		return v
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	return fmt.Sprintf(`"%s"`, r.Replace(v))
+}
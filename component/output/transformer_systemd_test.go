@@ -0,0 +1,57 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestSystemdTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		envFile  bool
+		data     string
+		expected string
+	}{
+		{
+			name:     "drop-in",
+			fileType: file.TypeJSON,
+			data:     `{"B":"say \"hi\"","A":"100%"}`,
+			expected: "[Service]\nEnvironment=\"A=100%%\"\nEnvironment=\"B=say \\\"hi\\\"\"\n",
+		},
+		{
+			name:     "nested yaml",
+			fileType: file.TypeYAML,
+			data:     "db:\n  host: localhost\n",
+			expected: "[Service]\nEnvironment=\"db_host=localhost\"\n",
+		},
+		{
+			name:     "env file",
+			fileType: file.TypeEnv,
+			data:     "PLAIN=value\nSPACE=\"a b\"\n",
+			envFile:  true,
+			expected: "PLAIN=value\nSPACE=\"a b\"\n",
+		},
+		{
+			name:     "variable names",
+			fileType: file.TypeJSON,
+			data:     `{"app-name":"api"}`,
+			envFile:  true,
+			expected: "app_name=api\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := SystemdTransformer{fileType: c.fileType, envFile: c.envFile}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
@@ -1,12 +1,7 @@
 package output
 
 import (
-	"bytes"
 	"encoding/json"
-	"fmt"
-
-	"github.com/dabblebox/stash/component/dotenv"
-	"github.com/dabblebox/stash/component/file"
 )
 
 type TaskDefEnvTransformer struct {
@@ -14,24 +9,20 @@ type TaskDefEnvTransformer struct {
 }
 
 func (t TaskDefEnvTransformer) Transform(data []byte) ([]byte, error) {
-	if t.fileType != file.TypeEnv {
-		return []byte{}, fmt.Errorf("transformer does not support %s files", t.fileType)
-	}
-
 	type EnvFormat struct {
 		Name  string `json:"name"`
 		Value string `json:"value"`
 	}
 
-	pairs, err := dotenv.Parse(bytes.NewReader(data))
+	pairs, err := parseFields(t.fileType, data)
 	if err != nil {
 		return data, err
 	}
 
 	env := []EnvFormat{}
-	for key, value := range pairs {
+	for _, key := range sortedNames(pairs) {
 		env = append(env, EnvFormat{
-			Value: value,
+			Value: pairs[key],
 			Name:  key,
 		})
 	}
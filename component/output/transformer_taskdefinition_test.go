@@ -0,0 +1,48 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestTaskDefEnvTransformer(t *testing.T) {
+	cases := []struct {
+		name     string
+		fileType string
+		data     string
+		expected string
+	}{
+		{
+			name:     "env",
+			fileType: file.TypeEnv,
+			data:     "B=2\nA=1\n",
+			expected: "[\n    {\n        \"name\": \"A\",\n        \"value\": \"1\"\n    },\n    {\n        \"name\": \"B\",\n        \"value\": \"2\"\n    }\n]",
+		},
+		{
+			name:     "nested json",
+			fileType: file.TypeJSON,
+			data:     `{"db":{"port":5432}}`,
+			expected: "[\n    {\n        \"name\": \"db_port\",\n        \"value\": \"5432\"\n    }\n]",
+		},
+		{
+			name:     "empty",
+			fileType: file.TypeEnv,
+			data:     "",
+			expected: "[]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := TaskDefEnvTransformer{fileType: c.fileType}.Transform([]byte(c.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
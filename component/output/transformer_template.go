@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+// TypeTemplatePrefix selects a user template. (e.g. template=deploy.tmpl)
+const TypeTemplatePrefix = "template="
+
+// TemplateFile is the catalog metadata available to templates.
+type TemplateFile struct {
+	Key       string
+	Path      string
+	Type      string
+	Service   string
+	Context   string
+	RemoteKey string
+
+	Keys    []string
+	Tags    []string
+	Options map[string]string
+
+	// ARNs maps each remote key to the AWS resource ARN.
+	ARNs map[string]string
+}
+
+// TemplateData is passed to user templates.
+type TemplateData struct {
+	File TemplateFile
+
+	// Values are the parsed key/value pairs for env, properties,
+	// JSON, and YAML files.
+	Values map[string]string
+
+	// Data is the original file data.
+	Data string
+}
+
+// TemplateTransformer renders the file through a user template.
+type TemplateTransformer struct {
+	Template string
+	File     TemplateFile
+}
+
+// TemplatePath returns the template selected by the output.
+func TemplatePath(output string) (string, bool) {
+	if !strings.HasPrefix(output, TypeTemplatePrefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(output, TypeTemplatePrefix), true
+}
+
+// TemplateFuncs are the helpers available to user templates.
+var TemplateFuncs = template.FuncMap{
+	"quote":  strconv.Quote,
+	"squote": func(s string) string { return fmt.Sprintf("'%s'", strings.Replace(s, "'", `'\''`, -1)) },
+	"base64": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"base64decode": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		return string(b), err
+	},
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"replace": func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+	"join":    func(sep string, a []string) string { return strings.Join(a, sep) },
+	"env":     envValue,
+}
+
+func (t TemplateTransformer) Transform(data []byte) ([]byte, error) {
+	text, err := file.Read(t.Template)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	tmpl, err := template.New(filepath.Base(t.Template)).Funcs(TemplateFuncs).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	values := map[string]string{}
+	if parsable(t.File.Type) {
+		if values, err = parseFields(t.File.Type, data); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, TemplateData{
+		File:   t.File,
+		Values: values,
+		Data:   string(data),
+	}); err != nil {
+		return []byte{}, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// envValue double quotes the value escaping characters interpreted by
+// env file parsers and shells.
+func envValue(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+
+	return fmt.Sprintf(`"%s"`, r.Replace(s))
+}
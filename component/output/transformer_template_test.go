@@ -0,0 +1,112 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dabblebox/stash/component/file"
+)
+
+func TestTemplateTransformer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		name     string
+		template string
+		file     TemplateFile
+		data     string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "values",
+			template: `{{ range $k, $v := .Values }}{{ $k }}={{ env $v }};{{ end }}`,
+			file:     TemplateFile{Type: file.TypeEnv},
+			data:     "B=$HOME\nA=1\n",
+			expected: `A="1";B="\$HOME";`,
+		},
+		{
+			name:     "metadata",
+			template: `{{ .File.Key }} {{ .File.RemoteKey }} {{ index .File.ARNs .File.RemoteKey }} {{ join "," .File.Tags }}`,
+			file: TemplateFile{
+				Key:       "config__env",
+				Type:      file.TypeEnv,
+				RemoteKey: "app/config",
+				Tags:      []string{"dev", "api"},
+				ARNs:      map[string]string{"app/config": "arn:aws:secretsmanager:us-east-1:123456789012:secret:app/config"},
+			},
+			data:     "A=1\n",
+			expected: "config__env app/config arn:aws:secretsmanager:us-east-1:123456789012:secret:app/config dev,api",
+		},
+		{
+			name:     "funcs",
+			template: `{{ quote .Values.A }} {{ squote .Values.B }} {{ base64 .Values.A | base64decode }} {{ upper .Values.A }} {{ replace "-" "_" .Values.A }}`,
+			file:     TemplateFile{Type: file.TypeJSON},
+			data:     `{"A":"a-b","B":"it's"}`,
+			expected: `"a-b" 'it'\''s' a-b A-B a_b`,
+		},
+		{
+			name:     "original data",
+			template: `{{ .Data | base64 }}`,
+			file:     TemplateFile{Type: "pem"},
+			data:     "key",
+			expected: "a2V5",
+		},
+		{
+			name:     "missing key",
+			template: `{{ .Values.MISSING }}`,
+			file:     TemplateFile{Type: file.TypeEnv},
+			data:     "A=1\n",
+			err:      true,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("%d.tmpl", i))
+			if err := ioutil.WriteFile(path, []byte(c.template), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := TemplateTransformer{Template: path, File: c.file}.Transform([]byte(c.data))
+			if c.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(actual) != c.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	cases := []struct {
+		output   string
+		expected string
+		ok       bool
+	}{
+		{output: "template=deploy.tmpl", expected: "deploy.tmpl", ok: true},
+		{output: TypeEnv, expected: "", ok: false},
+	}
+
+	for _, c := range cases {
+		actual, ok := TemplatePath(c.output)
+		if actual != c.expected || ok != c.ok {
+			t.Errorf("%s: expected %s %v, got %s %v", c.output, c.expected, c.ok, actual, ok)
+		}
+	}
+}
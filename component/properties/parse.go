@@ -0,0 +1,135 @@
+package properties
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parse reads Java properties returning the key/value pairs. Keys and
+// values are separated by '=', ':', or whitespace. Lines ending with
+// an odd number of backslashes continue on the next line.
+func Parse(r io.Reader) (map[string]string, error) {
+	props := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+
+	logical := ""
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+
+		if len(logical) == 0 && (len(line) == 0 || line[0] == '#' || line[0] == '!') {
+			continue
+		}
+
+		if continues(line) {
+			logical += line[:len(line)-1]
+			continue
+		}
+
+		logical += line
+
+		k, v, err := parseLine(logical)
+		if err != nil {
+			return props, err
+		}
+
+		props[k] = v
+		logical = ""
+	}
+
+	if len(logical) > 0 {
+		k, v, err := parseLine(logical)
+		if err != nil {
+			return props, err
+		}
+
+		props[k] = v
+	}
+
+	return props, scanner.Err()
+}
+
+// continues determines if the line ends with an unescaped backslash.
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+func parseLine(line string) (string, string, error) {
+	end := len(line)
+
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if strings.ContainsRune("=: \t\f", rune(line[i])) {
+			end = i
+			break
+		}
+	}
+
+	key, err := unescape(line[:end])
+	if err != nil {
+		return "", "", err
+	}
+
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if len(rest) > 0 && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	value, err := unescape(rest)
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+func unescape(s string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 >= len(s) {
+				return "", fmt.Errorf("invalid unicode escape in %q", s)
+			}
+
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape in %q", s)
+			}
+
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,42 @@
+package properties
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	data := `# comment
+! comment
+db.host = localhost
+db.port:5432
+name Stash App
+path=c:\\temp
+multi = one, \
+        two
+unicode=caf\u00e9
+key\=with\:separators=value
+empty=
+`
+
+	props, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"db.host":             "localhost",
+		"db.port":             "5432",
+		"name":                "Stash App",
+		"path":                `c:\temp`,
+		"multi":               "one, two",
+		"unicode":             "café",
+		"key=with:separators": "value",
+		"empty":               "",
+	}
+
+	if !reflect.DeepEqual(props, expected) {
+		t.Errorf("expected %v, got %v", expected, props)
+	}
+}
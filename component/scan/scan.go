@@ -0,0 +1,168 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Rules flagging likely secrets.
+const (
+	RuleAWSAccessKeyID     = "aws-access-key-id"
+	RuleAWSSecretAccessKey = "aws-secret-access-key"
+	RulePrivateKey         = "private-key"
+	RuleHighEntropy        = "high-entropy-string"
+)
+
+// AllowComment suppresses findings on the line containing it.
+const AllowComment = "stash:allow"
+
+const (
+	base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=_-" // stash:allow
+	hexChars    = "0123456789abcdefABCDEF"                                              // stash:allow
+
+	// Entropy thresholds in bits per character.
+	base64Entropy = 4.5
+	hexEntropy    = 3.0
+
+	minSecretLength = 20
+)
+
+var (
+	patterns = []struct {
+		rule string
+		re   *regexp.Regexp
+	}{
+		{RuleAWSAccessKeyID, regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{RuleAWSSecretAccessKey, regexp.MustCompile(`(?i)aws.{0,20}secret.{0,20}[:=]\s*["']?[0-9a-zA-Z/+]{40}\b`)},
+		{RulePrivateKey, regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	}
+
+	// assignment finds values assigned in config files and source code.
+	// (e.g. KEY=value, key: value, "key": "value")
+	assignment = regexp.MustCompile(`[\w.-]+["']?\s*(:=|=|:)\s*["']?([^\s"',;]+)`)
+)
+
+// Finding is a likely secret.
+type Finding struct {
+	Line int
+	Rule string
+}
+
+// Data finds likely secrets line by line. Lines containing the allow
+// comment are skipped.
+func Data(data []byte) []Finding {
+	findings := []Finding{}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for s.Scan() {
+		line++
+
+		text := s.Text()
+		if strings.Contains(text, AllowComment) {
+			continue
+		}
+
+		if rule, ok := matchLine(text); ok {
+			findings = append(findings, Finding{Line: line, Rule: rule})
+		}
+	}
+
+	return findings
+}
+
+// Binary determines if the data looks like a binary file.
+func Binary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+
+	return bytes.IndexByte(data, 0) > -1
+}
+
+func matchLine(text string) (string, bool) {
+	for _, p := range patterns {
+		if p.re.MatchString(text) {
+			return p.rule, true
+		}
+	}
+
+	for _, m := range assignment.FindAllStringSubmatch(text, -1) {
+		if HighEntropy(m[2]) {
+			return RuleHighEntropy, true
+		}
+	}
+
+	return "", false
+}
+
+// HighEntropy determines if the value looks like a random key or
+// token.
+func HighEntropy(value string) bool {
+	if len(value) < minSecretLength {
+		return false
+	}
+
+	switch {
+	case only(value, hexChars):
+		return strings.IndexAny(value, "0123456789") > -1 &&
+			strings.IndexAny(value, "abcdefABCDEF") > -1 &&
+			entropy(value) > hexEntropy
+	case only(value, base64Chars):
+		// Random tokens mix character classes; words and paths
+		// joined by separators do not.
+		return mixed(value) && entropy(value) > base64Entropy
+	}
+
+	return false
+}
+
+func only(s, chars string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(chars, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mixed(s string) bool {
+	upper, lower, digit := false, false, false
+
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		}
+	}
+
+	return upper && lower && digit
+}
+
+// entropy is the Shannon entropy in bits per character.
+func entropy(s string) float64 {
+	counts := map[rune]float64{}
+	for _, r := range s {
+		counts[r]++
+	}
+
+	e := 0.0
+	n := float64(len(s))
+
+	for _, c := range counts {
+		p := c / n
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
@@ -16,13 +16,18 @@ func By(regex string) ([]string, error) {
 
 	if err := filepath.Walk(".",
 		func(path string, info os.FileInfo, err error) error {
-			if info.IsDir() {
-				return nil
-			}
-
 			if err != nil {
 				return err
 			}
+
+			// Version control data is never configuration.
+			if info.IsDir() && info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			if info.IsDir() {
+				return nil
+			}
 			if re.MatchString(path) {
 				results = append(results, path)
 			}
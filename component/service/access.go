@@ -0,0 +1,70 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+
+	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service/aws/iac"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+// access is the infrastructure data collected by each service for a
+// file. The Terraform, CloudFormation, and Pulumi outputs all render
+// the same data.
+type access struct {
+	// Name is unique to the file and used for resource names.
+	Name string
+
+	// Policy grants read access to the remote data.
+	Policy policy.Policy
+
+	Secrets    []iac.Resource
+	Parameters []iac.Resource
+	Objects    []iac.Resource
+}
+
+// isIaC determines if the output is rendered from the access data
+// by renderIaC.
+func isIaC(format string) bool {
+	return format == output.TypeCloudFormation || format == output.TypePulumi || format == output.TypeIAMPolicy
+}
+
+// renderIaC renders the access data as a CloudFormation template,
+// Pulumi program, or IAM policy document.
+func renderIaC(format string, a access, file File) ([]byte, error) {
+	if format == output.TypeIAMPolicy {
+		return json.MarshalIndent(a.Policy, "", "    ")
+	}
+
+	p, err := json.Marshal(a.Policy)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	text := iac.CloudFormationTemplate
+	if format == output.TypePulumi {
+		text = iac.PulumiTemplate
+	}
+
+	tmpl, err := template.New(format).Funcs(iac.Funcs).Parse(text)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, iac.Model{
+		Context:    file.Context,
+		Name:       a.Name,
+		Path:       file.LocalPath,
+		Policy:     string(p),
+		Secrets:    a.Secrets,
+		Parameters: a.Parameters,
+		Objects:    a.Objects,
+	}); err != nil {
+		return []byte{}, err
+	}
+
+	return b.Bytes(), nil
+}
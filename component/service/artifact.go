@@ -0,0 +1,18 @@
+package service
+
+// Artifact is a generated file block shared by the files in a
+// Terraform module such as the KMS key or variables.
+type Artifact struct {
+	// Name is the file name within the module.
+	Name string
+
+	// ID identifies the managed block within the file.
+	ID string
+
+	// Data is the managed block content.
+	Data []byte
+
+	// Seed creates the content of user editable files that are
+	// only written when missing.
+	Seed func() ([]byte, error)
+}
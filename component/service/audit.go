@@ -0,0 +1,37 @@
+package service
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/dabblebox/stash/component/service/aws/audit"
+)
+
+// NewAuditor creates an auditor reading policies and CloudTrail events
+// between the start and end times.
+func NewAuditor(start, end time.Time) (*audit.Auditor, error) {
+	sess, err := session.NewSession(&aws.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return audit.New(audit.NewClients(sess), start, end), nil
+}
+
+// AuditResources converts the remote keys of a cataloged file into the
+// resources audited.
+func AuditResources(serviceKey string, keys []string, options map[string]string) []audit.Resource {
+	resources := []audit.Resource{}
+
+	for _, k := range keys {
+		resources = append(resources, audit.Resource{
+			Service:  serviceKey,
+			Key:      k,
+			Bucket:   options[S3BucketOption],
+			KMSKeyID: tfKMSKeyID(KMSKeyID(serviceKey, options)),
+		})
+	}
+
+	return resources
+}
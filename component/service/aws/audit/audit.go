@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+)
+
+// Services holding audited resources.
+const (
+	ServiceSecretsManager = "secrets-manager"
+	ServiceParameterStore = "parameter-store"
+	ServiceS3             = "s3"
+)
+
+// Sources describing how a principal can read a resource.
+const (
+	SourceSecretPolicy = "secret policy"
+	SourceBucketPolicy = "bucket policy"
+	SourceKeyPolicy    = "key policy"
+	SourceCloudTrail   = "cloudtrail"
+)
+
+// Clients are the AWS APIs read during an audit. Tests substitute
+// local stand-ins.
+type Clients struct {
+	SecretsManager secretsmanageriface.SecretsManagerAPI
+	S3             s3iface.S3API
+	KMS            kmsiface.KMSAPI
+	CloudTrail     cloudtrailiface.CloudTrailAPI
+}
+
+// NewClients ...
+func NewClients(sess *session.Session) Clients {
+	return Clients{
+		SecretsManager: secretsmanager.New(sess),
+		S3:             s3.New(sess),
+		KMS:            kms.New(sess),
+		CloudTrail:     cloudtrail.New(sess),
+	}
+}
+
+// Resource is a secret, parameter, or object holding file data.
+type Resource struct {
+	Service string
+
+	// Key is the secret name, parameter name, or object key.
+	Key string
+
+	// Bucket is the S3 bucket holding the object.
+	Bucket string
+
+	// KMSKeyID is the key id, ARN, or alias encrypting the data.
+	KMSKeyID string
+}
+
+// Reader is a principal that can read a resource or read it during
+// the audit window.
+type Reader struct {
+	Principal string `json:"principal"`
+
+	// Sources are the policies granting access and cloudtrail when
+	// reads were recorded.
+	Sources []string `json:"sources"`
+
+	// Reads is the number of recorded read events.
+	Reads int `json:"reads"`
+
+	// LastRead is the time of the latest recorded read event.
+	LastRead *time.Time `json:"lastRead,omitempty"`
+}
+
+// Auditor reports who can read stashed data. Policies and events are
+// cached; so, resources sharing buckets and keys are read once.
+type Auditor struct {
+	Clients Clients
+
+	// Start and End bound the CloudTrail events searched.
+	Start time.Time
+	End   time.Time
+
+	policies map[string][]string
+	events   map[string][]event
+}
+
+// New ...
+func New(c Clients, start, end time.Time) *Auditor {
+	return &Auditor{
+		Clients:  c,
+		Start:    start,
+		End:      end,
+		policies: map[string][]string{},
+		events:   map[string][]event{},
+	}
+}
+
+// Readers reports the principals allowed to read the resources by
+// resource and key policies along with the principals recorded
+// reading them.
+func (a *Auditor) Readers(resources []Resource) ([]Reader, error) {
+	readers := readerSet{}
+
+	for _, r := range resources {
+		switch r.Service {
+		case ServiceSecretsManager:
+			principals, err := a.secretPolicy(r.Key)
+			if err != nil {
+				return []Reader{}, err
+			}
+
+			readers.add(SourceSecretPolicy, principals...)
+		case ServiceS3:
+			principals, err := a.bucketPolicy(r.Bucket)
+			if err != nil {
+				return []Reader{}, err
+			}
+
+			readers.add(SourceBucketPolicy, principals...)
+		}
+
+		if len(r.KMSKeyID) > 0 {
+			principals, err := a.keyPolicy(r.KMSKeyID)
+			if err != nil {
+				return []Reader{}, err
+			}
+
+			readers.add(SourceKeyPolicy, principals...)
+		}
+
+		events, err := a.reads(r)
+		if err != nil {
+			return []Reader{}, err
+		}
+
+		for _, e := range events {
+			readers.read(e.principal(), e.EventTime)
+		}
+	}
+
+	return readers.list(), nil
+}
+
+// Summarize combines the readers of several files per principal.
+func Summarize(readers ...[]Reader) []Reader {
+	set := readerSet{}
+
+	for _, list := range readers {
+		for _, r := range list {
+			set.add("", r.Principal)
+
+			s := set[r.Principal]
+			for _, source := range r.Sources {
+				s.Sources = appendUnique(s.Sources, source)
+			}
+
+			s.Reads += r.Reads
+			if r.LastRead != nil && (s.LastRead == nil || r.LastRead.After(*s.LastRead)) {
+				t := *r.LastRead
+				s.LastRead = &t
+			}
+		}
+	}
+
+	return set.list()
+}
+
+type readerSet map[string]*Reader
+
+func (s readerSet) add(source string, principals ...string) {
+	for _, p := range principals {
+		r, ok := s[p]
+		if !ok {
+			r = &Reader{Principal: p, Sources: []string{}}
+			s[p] = r
+		}
+
+		if len(source) > 0 {
+			r.Sources = appendUnique(r.Sources, source)
+		}
+	}
+}
+
+func (s readerSet) read(principal string, t time.Time) {
+	s.add(SourceCloudTrail, principal)
+
+	r := s[principal]
+	r.Reads++
+
+	if r.LastRead == nil || t.After(*r.LastRead) {
+		r.LastRead = &t
+	}
+}
+
+func (s readerSet) list() []Reader {
+	readers := []Reader{}
+	for _, r := range s {
+		sort.Strings(r.Sources)
+		readers = append(readers, *r)
+	}
+
+	sort.Slice(readers, func(i, j int) bool { return readers[i].Principal < readers[j].Principal })
+
+	return readers
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
@@ -0,0 +1,203 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+//-------------------------------------
+//- Local Stand-ins
+//-------------------------------------
+
+type fakeSecretsManager struct {
+	secretsmanageriface.SecretsManagerAPI
+	policies map[string]string
+}
+
+func (f fakeSecretsManager) GetResourcePolicy(in *secretsmanager.GetResourcePolicyInput) (*secretsmanager.GetResourcePolicyOutput, error) {
+	p, ok := f.policies[aws.StringValue(in.SecretId)]
+	if !ok {
+		return &secretsmanager.GetResourcePolicyOutput{}, nil
+	}
+
+	return &secretsmanager.GetResourcePolicyOutput{ResourcePolicy: aws.String(p)}, nil
+}
+
+type fakeS3 struct {
+	s3iface.S3API
+	policies map[string]string
+}
+
+func (f fakeS3) GetBucketPolicy(in *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	p, ok := f.policies[aws.StringValue(in.Bucket)]
+	if !ok {
+		return nil, awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)
+	}
+
+	return &s3.GetBucketPolicyOutput{Policy: aws.String(p)}, nil
+}
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	aliases  map[string]string
+	policies map[string]string
+}
+
+func (f fakeKMS) DescribeKey(in *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	id := aws.StringValue(in.KeyId)
+	if k, ok := f.aliases[id]; ok {
+		id = k
+	}
+
+	return &kms.DescribeKeyOutput{KeyMetadata: &kms.KeyMetadata{KeyId: aws.String(id)}}, nil
+}
+
+func (f fakeKMS) GetKeyPolicy(in *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+	return &kms.GetKeyPolicyOutput{Policy: aws.String(f.policies[aws.StringValue(in.KeyId)])}, nil
+}
+
+type fakeCloudTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	events []event
+	calls  int
+}
+
+func (f *fakeCloudTrail) LookupEventsPages(in *cloudtrail.LookupEventsInput, fn func(*cloudtrail.LookupEventsOutput, bool) bool) error {
+	f.calls++
+
+	name := aws.StringValue(in.LookupAttributes[0].AttributeValue)
+
+	page := &cloudtrail.LookupEventsOutput{}
+	for _, e := range f.events {
+		if e.EventName != name || e.EventTime.Before(*in.StartTime) || e.EventTime.After(*in.EndTime) {
+			continue
+		}
+
+		b, _ := json.Marshal(e)
+		page.Events = append(page.Events, &cloudtrail.Event{CloudTrailEvent: aws.String(string(b))})
+	}
+
+	fn(page, true)
+
+	return nil
+}
+
+func newEvent(name, arn string, t time.Time, params map[string]interface{}) event {
+	e := event{EventName: name, EventTime: t, RequestParameters: params}
+	e.UserIdentity.ARN = arn
+
+	return e
+}
+
+func toJSON(p policy.Policy) string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+//-------------------------------------
+//- Tests
+//-------------------------------------
+
+func TestReaders(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(90 * 24 * time.Hour)
+
+	trail := &fakeCloudTrail{events: []event{
+		newEvent("GetSecretValue", "arn:aws:sts::123:assumed-role/app/i-1", start.Add(time.Hour), map[string]interface{}{
+			"secretId": "arn:aws:secretsmanager:us-east-1:123:secret:app/.env-AbCdEf",
+		}),
+		newEvent("GetSecretValue", "arn:aws:sts::123:assumed-role/app/i-2", start.Add(2*time.Hour), map[string]interface{}{
+			"secretId": "app/.env",
+		}),
+		newEvent("GetSecretValue", "arn:aws:iam::123:user/dev", start.Add(time.Hour), map[string]interface{}{
+			"secretId": "other/.env",
+		}),
+		newEvent("GetParameters", "arn:aws:iam::123:user/dev", start.Add(time.Hour), map[string]interface{}{
+			"names": []interface{}{"/app/.env/DB:2"},
+		}),
+		newEvent("GetObject", "arn:aws:iam::123:user/ops", end.Add(time.Hour), map[string]interface{}{
+			"bucketName": "configs", "key": "app/config.json",
+		}),
+	}}
+
+	a := New(Clients{
+		SecretsManager: fakeSecretsManager{policies: map[string]string{
+			"app/.env": toJSON(policy.Template("aws:userId", []string{"AIDAADMIN"}, []string{"AIDAADMIN", "AROAAPP:*"}, []string{"secretsmanager:PutSecretValue"}, []string{"secretsmanager:GetSecretValue"}, "*")),
+		}},
+		S3: fakeS3{policies: map[string]string{}},
+		KMS: fakeKMS{
+			aliases: map[string]string{"alias/app": "key-1"},
+			policies: map[string]string{"key-1": toJSON(policy.New(policy.Statement{
+				Effect:    "Allow",
+				Principal: policy.Principal{AWS: []string{"arn:aws:iam::123:role/app"}},
+				Action:    []string{"kms:*"},
+				Resource:  "*",
+			}))},
+		},
+		CloudTrail: trail,
+	}, start, end)
+
+	readers, err := a.Readers([]Resource{
+		{Service: ServiceSecretsManager, Key: "app/.env", KMSKeyID: "alias/app"},
+		{Service: ServiceParameterStore, Key: "/app/.env/DB", KMSKeyID: "alias/app"},
+		{Service: ServiceS3, Key: "app/config.json", Bucket: "configs"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]Reader{}
+	for _, r := range readers {
+		got[r.Principal] = r
+	}
+
+	if len(got) != 5 {
+		t.Fatalf("expected 5 readers, got %+v", readers)
+	}
+
+	if r := got["AROAAPP:*"]; !reflect.DeepEqual(r.Sources, []string{SourceSecretPolicy}) {
+		t.Errorf("unexpected role id sources %v", r.Sources)
+	}
+
+	if r := got["arn:aws:iam::123:role/app"]; !reflect.DeepEqual(r.Sources, []string{SourceKeyPolicy}) {
+		t.Errorf("unexpected key policy sources %v", r.Sources)
+	}
+
+	role := got["arn:aws:sts::123:assumed-role/app"]
+	if role.Reads != 2 || !role.LastRead.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("unexpected role reads %d last %v", role.Reads, role.LastRead)
+	}
+
+	if r := got["arn:aws:iam::123:user/dev"]; r.Reads != 1 {
+		t.Errorf("expected only the parameter read, got %d", r.Reads)
+	}
+
+	if _, ok := got["arn:aws:iam::123:user/ops"]; ok {
+		t.Errorf("event outside the window reported")
+	}
+
+	if trail.calls != 5 {
+		t.Errorf("expected events looked up once per event name, got %d lookups", trail.calls)
+	}
+
+	summary := Summarize(readers, readers)
+	for _, r := range summary {
+		if r.Principal == "arn:aws:sts::123:assumed-role/app" && r.Reads != 4 {
+			t.Errorf("expected reads summed, got %d", r.Reads)
+		}
+	}
+}
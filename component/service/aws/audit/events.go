@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+)
+
+// readEvents are the CloudTrail events reading each service's data.
+// S3 object reads are data events only recorded by trails with data
+// events enabled.
+var readEvents = map[string][]string{
+	ServiceSecretsManager: {"GetSecretValue"},
+	ServiceParameterStore: {"GetParameter", "GetParameters", "GetParametersByPath"},
+	ServiceS3:             {"GetObject"},
+}
+
+// event is the part of a CloudTrail event used to find the caller and
+// the data read.
+type event struct {
+	EventName string    `json:"eventName"`
+	EventTime time.Time `json:"eventTime"`
+
+	UserIdentity struct {
+		ARN       string `json:"arn"`
+		InvokedBy string `json:"invokedBy"`
+	} `json:"userIdentity"`
+
+	RequestParameters map[string]interface{} `json:"requestParameters"`
+}
+
+// principal returns the caller. Role sessions are reported as the
+// assumed role.
+func (e event) principal() string {
+	arn := e.UserIdentity.ARN
+
+	if len(arn) == 0 {
+		return e.UserIdentity.InvokedBy
+	}
+
+	if i := strings.Index(arn, ":assumed-role/"); i > -1 {
+		parts := strings.Split(arn[i+1:], "/")
+		if len(parts) > 2 {
+			return fmt.Sprintf("%s:%s", arn[:i], strings.Join(parts[:2], "/"))
+		}
+	}
+
+	return arn
+}
+
+// reads returns the recorded events reading the resource.
+func (a *Auditor) reads(r Resource) ([]event, error) {
+	matched := []event{}
+
+	for _, name := range readEvents[r.Service] {
+		events, err := a.lookup(name)
+		if err != nil {
+			return matched, err
+		}
+
+		for _, e := range events {
+			if e.reads(r) {
+				matched = append(matched, e)
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+func (a *Auditor) lookup(name string) ([]event, error) {
+	if events, ok := a.events[name]; ok {
+		return events, nil
+	}
+
+	events := []event{}
+
+	var parseErr error
+
+	if err := a.Clients.CloudTrail.LookupEventsPages(&cloudtrail.LookupEventsInput{
+		LookupAttributes: []*cloudtrail.LookupAttribute{{
+			AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyEventName),
+			AttributeValue: aws.String(name),
+		}},
+		StartTime: aws.Time(a.Start),
+		EndTime:   aws.Time(a.End),
+	}, func(page *cloudtrail.LookupEventsOutput, lastPage bool) bool {
+		for _, ce := range page.Events {
+			e := event{}
+			if err := json.Unmarshal([]byte(aws.StringValue(ce.CloudTrailEvent)), &e); err != nil {
+				parseErr = fmt.Errorf("cloudtrail event %s: %s", aws.StringValue(ce.EventId), err)
+				return false
+			}
+
+			events = append(events, e)
+		}
+
+		return true
+	}); err != nil {
+		return events, fmt.Errorf("cloudtrail %s events: %s", name, err)
+	}
+
+	if parseErr != nil {
+		return events, parseErr
+	}
+
+	a.events[name] = events
+
+	return events, nil
+}
+
+// reads determines if the event read the resource.
+func (e event) reads(r Resource) bool {
+	params := e.RequestParameters
+
+	switch e.EventName {
+	case "GetSecretValue":
+		return secretIDMatches(str(params["secretId"]), r.Key)
+	case "GetParameter":
+		return parameterName(str(params["name"])) == r.Key
+	case "GetParameters":
+		names, _ := params["names"].([]interface{})
+		for _, n := range names {
+			if parameterName(str(n)) == r.Key {
+				return true
+			}
+		}
+	case "GetParametersByPath":
+		path := strings.TrimSuffix(str(params["path"]), "/") + "/"
+		if !strings.HasPrefix(r.Key, path) {
+			return false
+		}
+
+		// Only recursive requests read nested parameters.
+		return params["recursive"] == true || !strings.Contains(strings.TrimPrefix(r.Key, path), "/")
+	case "GetObject":
+		return str(params["bucketName"]) == r.Bucket && str(params["key"]) == r.Key
+	}
+
+	return false
+}
+
+// secretIDMatches compares a secret name or ARN to the secret name.
+// Secret ARNs end with a random suffix. (e.g. secret:app/.env-AbCdEf)
+func secretIDMatches(id, name string) bool {
+	if id == name {
+		return true
+	}
+
+	i := strings.Index(id, ":secret:")
+	if i == -1 {
+		return false
+	}
+
+	n := id[i+len(":secret:"):]
+
+	return n == name || (strings.HasPrefix(n, name+"-") && len(n) == len(name)+7)
+}
+
+// parameterName strips ARN prefixes and versions or labels from a
+// parameter name.
+func parameterName(name string) string {
+	if i := strings.Index(name, ":parameter"); strings.HasPrefix(name, "arn:") && i > -1 {
+		name = name[i+len(":parameter"):]
+	}
+
+	if i := strings.LastIndex(name, ":"); i > -1 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,205 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+var (
+	secretReadActions = []string{"secretsmanager:GetSecretValue"}
+	objectReadActions = []string{"s3:GetObject"}
+	keyReadActions    = []string{"kms:Decrypt"}
+)
+
+// principalConditions narrow a wildcard principal to the matching
+// callers. Stash policies restrict readers by aws:userId or aws:arn.
+var principalConditions = []string{"aws:userid", "aws:arn", "aws:principalarn"}
+
+// accountConditions narrow a wildcard principal to an account. AWS
+// managed key policies allow any caller in the account.
+var accountConditions = []string{"kms:calleraccount", "aws:principalaccount"}
+
+func (a *Auditor) secretPolicy(secretID string) ([]string, error) {
+	return a.cached(fmt.Sprintf("secret:%s", secretID), func() ([]string, error) {
+		o, err := a.Clients.SecretsManager.GetResourcePolicy(&secretsmanager.GetResourcePolicyInput{
+			SecretId: aws.String(secretID),
+		})
+		if err != nil {
+			return []string{}, fmt.Errorf("%s: %s", secretID, err)
+		}
+
+		return policyReaders(aws.StringValue(o.ResourcePolicy), secretReadActions)
+	})
+}
+
+func (a *Auditor) bucketPolicy(bucket string) ([]string, error) {
+	return a.cached(fmt.Sprintf("bucket:%s", bucket), func() ([]string, error) {
+		o, err := a.Clients.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchBucketPolicy" {
+				return []string{}, nil
+			}
+
+			return []string{}, fmt.Errorf("%s: %s", bucket, err)
+		}
+
+		return policyReaders(aws.StringValue(o.Policy), objectReadActions)
+	})
+}
+
+func (a *Auditor) keyPolicy(keyID string) ([]string, error) {
+	return a.cached(fmt.Sprintf("key:%s", keyID), func() ([]string, error) {
+		// Key policies are only found by key id or ARN.
+		d, err := a.Clients.KMS.DescribeKey(&kms.DescribeKeyInput{
+			KeyId: aws.String(keyID),
+		})
+		if err != nil {
+			return []string{}, fmt.Errorf("%s: %s", keyID, err)
+		}
+
+		o, err := a.Clients.KMS.GetKeyPolicy(&kms.GetKeyPolicyInput{
+			KeyId:      d.KeyMetadata.KeyId,
+			PolicyName: aws.String("default"),
+		})
+		if err != nil {
+			return []string{}, fmt.Errorf("%s: %s", keyID, err)
+		}
+
+		return policyReaders(aws.StringValue(o.Policy), keyReadActions)
+	})
+}
+
+func (a *Auditor) cached(key string, read func() ([]string, error)) ([]string, error) {
+	if p, ok := a.policies[key]; ok {
+		return p, nil
+	}
+
+	p, err := read()
+	if err != nil {
+		return p, err
+	}
+
+	a.policies[key] = p
+
+	return p, nil
+}
+
+// policyReaders lists the principals allowed to perform any of the
+// actions. Wildcard principals are narrowed by principal conditions
+// when present.
+func policyReaders(doc string, actions []string) ([]string, error) {
+	principals := []string{}
+
+	if len(doc) == 0 {
+		return principals, nil
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		return principals, fmt.Errorf("policy invalid: %s", err)
+	}
+
+	statements, ok := m["Statement"].([]interface{})
+	if !ok {
+		statements = []interface{}{m["Statement"]}
+	}
+
+	for _, s := range statements {
+		st, ok := s.(map[string]interface{})
+		if !ok || st["Effect"] != "Allow" || !matchesAny(policy.Strings(st["Action"]), actions) {
+			continue
+		}
+
+		for _, p := range statementPrincipals(st) {
+			principals = appendUnique(principals, p)
+		}
+	}
+
+	return principals, nil
+}
+
+func statementPrincipals(st map[string]interface{}) []string {
+	principals := []string{}
+
+	switch p := st["Principal"].(type) {
+	case string:
+		principals = append(principals, p)
+	case map[string]interface{}:
+		principals = append(principals, policy.Strings(p["AWS"])...)
+	}
+
+	wildcard := false
+	for _, p := range principals {
+		if p == "*" {
+			wildcard = true
+		}
+	}
+
+	if !wildcard {
+		return principals
+	}
+
+	cond, _ := st["Condition"].(map[string]interface{})
+
+	narrowed := []string{}
+	for operator, values := range cond {
+		if strings.HasPrefix(operator, "StringNot") || strings.HasPrefix(operator, "ArnNot") {
+			continue
+		}
+
+		v, _ := values.(map[string]interface{})
+		for k, list := range v {
+			for _, c := range principalConditions {
+				if strings.ToLower(k) == c {
+					narrowed = append(narrowed, policy.Strings(list)...)
+				}
+			}
+
+			for _, c := range accountConditions {
+				if strings.ToLower(k) != c {
+					continue
+				}
+
+				for _, account := range policy.Strings(list) {
+					narrowed = append(narrowed, fmt.Sprintf("arn:aws:iam::%s:root", account))
+				}
+			}
+		}
+	}
+
+	if len(narrowed) > 0 {
+		return narrowed
+	}
+
+	return principals
+}
+
+// matchesAny determines if a policy action pattern allows any of the
+// actions. Patterns are case insensitive and support wildcards.
+func matchesAny(patterns, actions []string) bool {
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)^" + strings.Replace(regexp.QuoteMeta(p), `\*`, ".*", -1) + "$")
+		if err != nil {
+			continue
+		}
+
+		for _, a := range actions {
+			if re.MatchString(a) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
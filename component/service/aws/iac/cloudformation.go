@@ -0,0 +1,53 @@
+package iac
+
+// CloudFormationTemplate grants roles read access to the configuration
+// through an IAM managed policy. The secrets already exist; so, they
+// must be imported into the stack with an IMPORT change set instead of
+// created. They are retained when the stack is deleted.
+// Parameters are referenced by ARN since CloudFormation does not
+// support SecureString parameters.
+var CloudFormationTemplate = `AWSTemplateFormatVersion: "2010-09-09"
+Description: Access to {{ .Context }} configuration managed by Stash.
+Parameters:
+  ConfigIAMRoles:
+    Type: CommaDelimitedList
+    Description: Role names granted read access to the configuration.
+Resources:
+  {{ logicalID .Name }}Policy:
+    Type: AWS::IAM::ManagedPolicy
+    Properties:
+      ManagedPolicyName: {{ quote .Name }}
+      Description: {{ quote (printf "Read access to %s managed by Stash." .Path) }}
+      Roles:
+        Ref: ConfigIAMRoles
+      PolicyDocument: {{ .Policy }}
+{{- range .Secrets }}
+  {{ logicalID .Name }}Secret:
+    Type: AWS::SecretsManager::Secret
+    DeletionPolicy: Retain
+    UpdateReplacePolicy: Retain
+    Properties:
+      Name: {{ quote .Key }}
+      Description: Managed by Stash
+{{- if .KMSKeyID }}
+      KmsKeyId: {{ quote .KMSKeyID }}
+{{- end }}
+{{- end }}
+Outputs:
+  {{ logicalID .Name }}PolicyArn:
+    Value:
+      Ref: {{ logicalID .Name }}Policy
+{{- range .Secrets }}
+  {{ logicalID .Name }}SecretArn:
+    Value:
+      Ref: {{ logicalID .Name }}Secret
+{{- end }}
+{{- range .Parameters }}
+  {{ logicalID .Name }}ParameterArn:
+    Value: {{ quote .ARN }}
+{{- end }}
+{{- range .Objects }}
+  {{ logicalID .Name }}ObjectArn:
+    Value: {{ quote .ARN }}
+{{- end }}
+`
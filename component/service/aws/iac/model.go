@@ -0,0 +1,60 @@
+package iac
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Model is the infrastructure data collected for a cataloged file
+// and rendered by the CloudFormation and Pulumi templates.
+type Model struct {
+	Context string
+
+	// Name is unique to the file and used for resource names.
+	Name string
+
+	// Path is the local file path.
+	Path string
+
+	// Policy is the JSON policy document granting read access.
+	Policy string
+
+	Secrets    []Resource
+	Parameters []Resource
+	Objects    []Resource
+}
+
+// Resource is a secret, parameter, or S3 object holding the file data.
+type Resource struct {
+	Name     string
+	Key      string
+	ARN      string
+	Type     string
+	KMSKeyID string
+	Bucket   string
+}
+
+// Funcs are the helpers available to the templates.
+var Funcs = template.FuncMap{
+	"quote":     strconv.Quote,
+	"logicalID": LogicalID,
+}
+
+// LogicalID converts a resource name into an alphanumeric CloudFormation
+// logical id. (e.g. my_app_env => MyAppEnv)
+func LogicalID(name string) string {
+	parts := regexp.MustCompile(`[^a-zA-Z0-9]+`).Split(name, -1)
+
+	var b strings.Builder
+	for _, p := range parts {
+		if len(p) == 0 {
+			continue
+		}
+
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package iac
+
+// PulumiTemplate is a Pulumi YAML program granting a role read access
+// to the configuration. Existing secrets, parameters, and S3 objects
+// are imported while the values remain managed by Stash. The policy is
+// attached per role since aws:iam:PolicyAttachment detaches the policy
+// from every role it does not list; Pulumi YAML cannot loop over a
+// list of roles.
+var PulumiTemplate = `name: {{ .Context }}-config
+runtime: yaml
+description: Access to {{ .Context }} configuration managed by Stash.
+config:
+  configIAMRole:
+    type: String
+resources:
+  {{ .Name }}-policy:
+    type: aws:iam:Policy
+    properties:
+      name: {{ quote .Name }}
+      description: {{ quote (printf "Read access to %s managed by Stash." .Path) }}
+      policy:
+        fn::toJSON: {{ .Policy }}
+  {{ .Name }}-attachment:
+    type: aws:iam:RolePolicyAttachment
+    properties:
+      policyArn: ${ {{- .Name }}-policy.arn}
+      role: ${configIAMRole}
+{{- range .Secrets }}
+  secret-{{ .Name }}:
+    type: aws:secretsmanager:Secret
+    properties:
+      name: {{ quote .Key }}
+      description: Managed by Stash
+{{- if .KMSKeyID }}
+      kmsKeyId: {{ quote .KMSKeyID }}
+{{- end }}
+    options:
+      import: {{ quote .ARN }}
+      retainOnDelete: true
+      ignoreChanges:
+        - tags
+{{- end }}
+{{- range .Parameters }}
+  param-{{ .Name }}:
+    type: aws:ssm:Parameter
+    properties:
+      name: {{ quote .Key }}
+      type: {{ .Type }}
+      value: managed-by-stash
+{{- if .KMSKeyID }}
+      keyId: {{ quote .KMSKeyID }}
+{{- end }}
+    options:
+      import: {{ quote .Key }}
+      retainOnDelete: true
+      ignoreChanges:
+        - value
+        - tags
+{{- end }}
+{{- range .Objects }}
+  object-{{ .Name }}:
+    type: aws:s3:BucketObjectv2
+    properties:
+      bucket: {{ quote .Bucket }}
+      key: {{ quote .Key }}
+    options:
+      import: {{ quote (printf "%s/%s" .Bucket .Key) }}
+      retainOnDelete: true
+      ignoreChanges:
+        - content
+        - source
+        - etag
+        - tags
+{{- end }}
+outputs:
+  {{ .Name }}-policy-arn: ${ {{- .Name }}-policy.arn}
+`
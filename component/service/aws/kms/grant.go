@@ -0,0 +1,136 @@
+package kms
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+const (
+	// PolicyName is the only key policy name supported by KMS.
+	PolicyName = "default"
+
+	// grantSid is added to key policies not created by Stash.
+	grantSid = "StashDecrypt"
+
+	userIDCondition = "aws:userId"
+)
+
+// Principal is an IAM role or user granted decrypt access.
+type Principal struct {
+	// ARN is used by key policies not created by Stash.
+	ARN string
+
+	// ID is matched against aws:userId in Stash key policies.
+	ID string
+}
+
+// GetPolicy returns the key policy document.
+func GetPolicy(keyID string, svc *kms.KMS) (string, error) {
+	o, err := svc.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyID),
+		PolicyName: aws.String(PolicyName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(o.Policy), nil
+}
+
+// PutPolicy replaces the key policy document.
+func PutPolicy(keyID, doc string, svc *kms.KMS) error {
+	_, err := svc.PutKeyPolicy(&kms.PutKeyPolicyInput{
+		KeyId:      aws.String(keyID),
+		PolicyName: aws.String(PolicyName),
+		Policy:     aws.String(doc),
+	})
+
+	return err
+}
+
+// UpdateReaders grants or revokes decrypt access in the key policy.
+// Stash key policies restrict access by user id; other key policies
+// receive a separate statement listing the principal ARNs. Statements
+// not managed by Stash are left untouched.
+func UpdateReaders(doc string, p Principal, grant bool) (string, bool, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		return doc, false, fmt.Errorf("key policy invalid: %s", err)
+	}
+
+	statements := toList(m["Statement"])
+
+	stash, changed := policy.UpdateTemplateReaders(statements, userIDCondition, p.ID, grant)
+
+	if !stash {
+		statements, changed = updateGrantStatement(statements, p.ARN, grant)
+	}
+
+	if !changed {
+		return doc, false, nil
+	}
+
+	m["Statement"] = statements
+
+	b, err := json.MarshalIndent(m, "", "   ")
+	if err != nil {
+		return doc, false, err
+	}
+
+	return string(b), true, nil
+}
+
+// updateGrantStatement adds or removes the principal from the Stash
+// decrypt statement removing the statement when empty.
+func updateGrantStatement(statements []interface{}, principalARN string, grant bool) ([]interface{}, bool) {
+	for i, s := range statements {
+		st, ok := s.(map[string]interface{})
+		if !ok || st["Sid"] != grantSid {
+			continue
+		}
+
+		principal, _ := st["Principal"].(map[string]interface{})
+		if principal == nil {
+			principal = map[string]interface{}{}
+			st["Principal"] = principal
+		}
+
+		arns, changed := policy.UpdateList(policy.Strings(principal["AWS"]), principalARN, grant)
+		if len(arns) == 0 {
+			return append(statements[:i], statements[i+1:]...), changed
+		}
+
+		principal["AWS"] = arns
+
+		return statements, changed
+	}
+
+	if !grant {
+		return statements, false
+	}
+
+	return append(statements, map[string]interface{}{
+		"Sid":       grantSid,
+		"Effect":    "Allow",
+		"Principal": map[string]interface{}{"AWS": []string{principalARN}},
+		"Action":    readActions,
+		"Resource":  "*",
+	}), true
+}
+
+// toList normalizes policy statements that are either a single
+// statement or a list.
+func toList(v interface{}) []interface{} {
+	switch t := v.(type) {
+	case nil:
+		return []interface{}{}
+	case []interface{}:
+		return t
+	}
+
+	return []interface{}{v}
+}
@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// readerSids are the statements created by Template restricting read
+// access with a condition.
+var readerSids = map[string]string{
+	"DenyReadToAllExceptRoleAndSAMLUsers": "StringNotLike",
+	"AllowReadRoleAndSAMLUsers":           "StringLike",
+}
+
+// Merge combines the statements of each policy. Allow statements with
+// the same actions are merged into a single statement listing every
+// resource.
+func Merge(policies ...Policy) Policy {
+	merged := []Statement{}
+	index := map[string]int{}
+
+	for _, p := range policies {
+		for _, s := range p.Statement {
+			if s.Effect != "Allow" || s.Principal != nil || s.Condition != nil {
+				merged = append(merged, s)
+				continue
+			}
+
+			actions := append([]string{}, s.Action...)
+			sort.Strings(actions)
+
+			key := strings.Join(actions, ",")
+
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(merged)
+				merged = append(merged, Statement{
+					Effect:   s.Effect,
+					Action:   actions,
+					Resource: Strings(s.Resource),
+				})
+				continue
+			}
+
+			resources := Strings(merged[i].Resource)
+			for _, r := range Strings(s.Resource) {
+				resources, _ = UpdateList(resources, r, true)
+			}
+
+			merged[i].Resource = resources
+		}
+	}
+
+	for i := range merged {
+		if r, ok := merged[i].Resource.([]string); ok {
+			sort.Strings(r)
+		}
+	}
+
+	return New(merged...)
+}
+
+// UpdateTemplateReaders adds or removes the value from the read
+// conditions of the statements created by Template. False is returned
+// for found when the statements do not exist.
+func UpdateTemplateReaders(statements []interface{}, conditionType, value string, grant bool) (found bool, changed bool) {
+	for _, s := range statements {
+		st, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		operator, ok := readerSids[fmt.Sprintf("%v", st["Sid"])]
+		if !ok {
+			continue
+		}
+
+		found = true
+
+		cond, _ := st["Condition"].(map[string]interface{})
+		values, _ := cond[operator].(map[string]interface{})
+		if values == nil {
+			continue
+		}
+
+		list, c := UpdateList(Strings(values[conditionType]), value, grant)
+		values[conditionType] = list
+		changed = changed || c
+	}
+
+	return found, changed
+}
+
+// UpdateList adds or removes a value. False is returned when the list
+// is unchanged.
+func UpdateList(list []string, value string, add bool) ([]string, bool) {
+	out := []string{}
+	found := false
+
+	for _, v := range list {
+		if v == value {
+			found = true
+			if !add {
+				continue
+			}
+		}
+
+		out = append(out, v)
+	}
+
+	if add && !found {
+		out = append(out, value)
+	}
+
+	return out, found != add
+}
+
+// Strings normalizes policy values that are either a single value or
+// a list.
+func Strings(v interface{}) []string {
+	switch t := v.(type) {
+	case nil:
+		return []string{}
+	case string:
+		return []string{t}
+	case []string:
+		return append([]string{}, t...)
+	case *[]string:
+		return append([]string{}, *t...)
+	case []interface{}:
+		out := []string{}
+		for _, i := range t {
+			out = append(out, fmt.Sprintf("%v", i))
+		}
+		return out
+	}
+
+	return []string{fmt.Sprintf("%v", v)}
+}
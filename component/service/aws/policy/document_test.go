@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	arns := []string{"arn:b"}
+
+	p := Merge(
+		New(Statement{Effect: "Allow", Action: []string{"secretsmanager:GetSecretValue"}, Resource: &arns}),
+		New(Statement{Effect: "Allow", Action: []string{"secretsmanager:GetSecretValue"}, Resource: []interface{}{"arn:a", "arn:b"}}),
+		New(Statement{Effect: "Allow", Action: []string{"s3:GetObject"}, Resource: "arn:c"}),
+	)
+
+	if len(p.Statement) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(p.Statement))
+	}
+
+	if r := p.Statement[0].Resource; !reflect.DeepEqual(r, []string{"arn:a", "arn:b"}) {
+		t.Errorf("unexpected resources %v", r)
+	}
+
+	if r := p.Statement[1].Resource; !reflect.DeepEqual(r, []string{"arn:c"}) {
+		t.Errorf("unexpected resources %v", r)
+	}
+}
+
+func TestUpdateTemplateReaders(t *testing.T) {
+	b, _ := json.Marshal(Template("aws:arn", []string{"admin"}, []string{"admin"}, []string{"write"}, []string{"read"}, "*"))
+
+	m := map[string]interface{}{}
+	json.Unmarshal(b, &m)
+
+	statements := m["Statement"].([]interface{})
+
+	if found, changed := UpdateTemplateReaders(statements, "aws:arn", "app", true); !found || !changed {
+		t.Fatalf("expected reader added, found %t changed %t", found, changed)
+	}
+
+	if _, changed := UpdateTemplateReaders(statements, "aws:arn", "app", true); changed {
+		t.Errorf("expected existing reader unchanged")
+	}
+
+	readers := statements[1].(map[string]interface{})["Condition"].(map[string]interface{})["StringNotLike"].(map[string]interface{})["aws:arn"]
+	if !reflect.DeepEqual(readers, []string{"admin", "app"}) {
+		t.Errorf("unexpected readers %v", readers)
+	}
+
+	writers := statements[0].(map[string]interface{})["Condition"].(map[string]interface{})["StringNotLike"].(map[string]interface{})["aws:arn"]
+	if !reflect.DeepEqual(Strings(writers), []string{"admin"}) {
+		t.Errorf("writers changed %v", writers)
+	}
+
+	if found, _ := UpdateTemplateReaders([]interface{}{}, "aws:arn", "app", true); found {
+		t.Errorf("expected statements not found")
+	}
+}
@@ -0,0 +1,13 @@
+package ps
+
+type HCLParameter struct {
+	Name     string
+	Key      string
+	Type     string
+	Tier     string
+	KMSKeyID string
+
+	Policies []string
+
+	Tags map[string]string
+}
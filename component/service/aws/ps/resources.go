@@ -0,0 +1,43 @@
+package ps
+
+type HCLResourcesModel struct {
+	Parameters []HCLParameter
+
+	// Variables references parameter values through Terraform
+	// variables instead of leaving the values to Stash.
+	Variables bool
+}
+
+var HCLResourcesTemplate = `# Terraform owns the parameters while Stash owns the values. The import blocks adopt the
+# existing parameters into Terraform state. (requires Terraform 1.5 or later)
+{{range .Parameters }}
+import {
+	to = aws_ssm_parameter.param_{{ .Name }}
+	id = "{{ .Key }}"
+}
+{{if $.Variables }}
+variable "param_{{ .Name }}" {
+	type      = string
+	sensitive = true
+}
+{{end}}
+resource "aws_ssm_parameter" "param_{{ .Name }}" {
+	name  = "{{ .Key }}"
+	type  = "{{ .Type }}"
+	tier  = "{{ .Tier }}"
+{{if $.Variables }}	value = var.param_{{ .Name }}{{else}}	value = "managed-by-stash"{{end}}
+{{if .KMSKeyID }}
+	key_id = "{{ .KMSKeyID }}"
+{{end}}{{if .Tags }}
+	tags = { {{range $k, $v := .Tags }}
+		"{{ $k }}" = "{{ $v }}"{{end}}
+	}
+{{end}}{{if .Policies }}
+	# Parameter policies are applied by Stash.{{range .Policies }}
+	# {{ . }}{{end}}
+{{end}}{{if not $.Variables }}
+	lifecycle {
+		ignore_changes = [value]
+	}
+{{end}}}
+{{end}}`
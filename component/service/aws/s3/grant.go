@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+)
+
+const arnCondition = "aws:arn"
+
+// GetPolicy returns the bucket policy document.
+func GetPolicy(bucket string, svc *s3.S3) (string, error) {
+	o, err := svc.GetBucketPolicy(&s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(o.Policy), nil
+}
+
+// PutPolicy replaces the bucket policy document.
+func PutPolicy(bucket, doc string, svc *s3.S3) error {
+	_, err := svc.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(doc),
+	})
+
+	return err
+}
+
+// UpdateReaders grants or revokes read access in a bucket policy
+// created by Stash. Readers are matched against aws:arn; so, roles
+// are granted through their assumed role sessions.
+func UpdateReaders(doc, readerARN string, grant bool) (string, bool, error) {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(doc), &m); err != nil {
+		return doc, false, fmt.Errorf("bucket policy invalid: %s", err)
+	}
+
+	statements, _ := m["Statement"].([]interface{})
+
+	stash, changed := policy.UpdateTemplateReaders(statements, arnCondition, readerARN, grant)
+	if !stash {
+		return doc, false, errors.New("bucket policy not created by Stash")
+	}
+
+	if !changed {
+		return doc, false, nil
+	}
+
+	b, err := json.MarshalIndent(m, "", "   ")
+	if err != nil {
+		return doc, false, err
+	}
+
+	return string(b), true, nil
+}
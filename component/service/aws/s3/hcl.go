@@ -1,6 +1,7 @@
 package s3
 
 type HCLModel struct {
+	Name   string
 	Bucket string
 
 	Tags map[string]string
@@ -18,10 +19,10 @@ var HCLTemplate = `# This Terraform is an example of what a secure S3 bucket and
 #
 # To manage the S3 bucket through Terraform run the following commands.
 #
-# $ terraform import aws_s3_bucket.config {{ .Bucket }}
-# $ terraform import aws_s3_bucket_public_access_block.config_public_bucket_policy {{ .Bucket }}
+# $ terraform import aws_s3_bucket.config_{{ .Name }} {{ .Bucket }}
+# $ terraform import aws_s3_bucket_public_access_block.config_public_bucket_policy_{{ .Name }} {{ .Bucket }}
 
-resource "aws_s3_bucket" "config" {
+resource "aws_s3_bucket" "config_{{ .Name }}" {
   bucket        = "{{ .Bucket }}"
 
   # These two properties may show changes are required during Terraform plam,
@@ -45,13 +46,13 @@ resource "aws_s3_bucket" "config" {
   }
 }
 
-resource "aws_s3_bucket_policy" "config" {
-  bucket = aws_s3_bucket.config.id
+resource "aws_s3_bucket_policy" "config_{{ .Name }}" {
+  bucket = aws_s3_bucket.config_{{ .Name }}.id
 
-  policy = data.template_file.s3_policy.rendered
+  policy = data.template_file.s3_policy_{{ .Name }}.rendered
 }
 
-data "template_file" "s3_policy" {
+data "template_file" "s3_policy_{{ .Name }}" {
   template = <<EOF
 {
    "Version": "2012-10-17",
@@ -173,8 +174,8 @@ EOF
   }
 }
 
-resource "aws_s3_bucket_public_access_block" "config_public_bucket_policy" {
-  bucket = aws_s3_bucket.config.id
+resource "aws_s3_bucket_public_access_block" "config_public_bucket_policy_{{ .Name }}" {
+  bucket = aws_s3_bucket.config_{{ .Name }}.id
 
   block_public_acls   = true
   block_public_policy = true
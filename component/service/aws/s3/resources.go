@@ -0,0 +1,40 @@
+package s3
+
+type HCLResourcesModel struct {
+	Name     string
+	Bucket   string
+	Key      string
+	KMSKeyID string
+
+	// Variables references the object content through a Terraform
+	// variable instead of leaving the content to Stash.
+	Variables bool
+}
+
+var HCLResourcesTemplate = `# Terraform owns the object while Stash owns the content. The import block adopts the
+# existing object into Terraform state. (requires Terraform 1.5 or later)
+
+import {
+	to = aws_s3_object.object_{{ .Name }}
+	id = "{{ .Bucket }}/{{ .Key }}"
+}
+{{if .Variables }}
+variable "object_{{ .Name }}" {
+	type      = string
+	sensitive = true
+}
+{{end}}
+resource "aws_s3_object" "object_{{ .Name }}" {
+	bucket = "{{ .Bucket }}"
+	key    = "{{ .Key }}"
+{{if .KMSKeyID }}
+	server_side_encryption = "aws:kms"
+	kms_key_id             = "{{ .KMSKeyID }}"
+{{end}}{{if .Variables }}
+	content = var.object_{{ .Name }}
+{{else}}
+	lifecycle {
+		ignore_changes = [content, content_base64, source, etag, tags]
+	}
+{{end}}}
+`
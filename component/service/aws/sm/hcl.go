@@ -1,24 +1,18 @@
 package sm
 
+import "github.com/dabblebox/stash/component/service/aws/iac"
+
 type HCLModel struct {
-	Arns map[string]string
+	Secrets []iac.Resource
 }
 
-var HCLSecretsTemplate = `# By default, secrets can be altered by other users with power user access. Use the 
-# import commands to take control of each secret's policy. After applying the Terraform, 
-# the secrets will be locked down to only specified users and roles and encrypted with
-# a KMS key that is locked down also. 
-#{{range $i, $a := .Arns }}
-# $ terraform import aws_secretsmanager_secret.secret_{{ $i }} {{ $a }}{{end}}
-{{range $i, $a := .Arns }}
-resource "aws_secretsmanager_secret" "secret_{{ $i }}" {
-	description = "Created by Stash"
-	
-	kms_key_id = aws_kms_key.config.key_id
-	
-	tags = var.tags
-	
-	policy = data.template_file.sm_policy.rendered
+var HCLSecretPoliciesTemplate = `# By default, secrets can be altered by other users with power user access. After 
+# applying the Terraform, each secret will be locked down to only specified users and
+# roles. Use the terraform-resources output to manage the secrets themselves.
+{{range .Secrets }}
+resource "aws_secretsmanager_secret_policy" "secret_policy_{{ .Name }}" {
+	secret_arn = "{{ .ARN }}"
+	policy     = data.template_file.sm_policy.rendered
 }
 {{end}}`
 
@@ -131,12 +125,3 @@ vars = {
     )
   }
 }`
-
-func NotLast(m map[string]string) func() bool {
-	i := 0
-
-	return func() bool {
-		i++
-		return (i != len(m))
-	}
-}
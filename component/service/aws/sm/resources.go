@@ -0,0 +1,41 @@
+package sm
+
+import "github.com/dabblebox/stash/component/service/aws/iac"
+
+type HCLResourcesModel struct {
+	Secrets []iac.Resource
+
+	// Variables references secret values through Terraform
+	// variables instead of leaving the values to Stash.
+	Variables bool
+}
+
+var HCLResourcesTemplate = `# Terraform owns the secrets while Stash owns the values. The import blocks adopt the
+# existing secrets into Terraform state. (requires Terraform 1.5 or later)
+{{range .Secrets }}
+import {
+	to = aws_secretsmanager_secret.secret_{{ .Name }}
+	id = "{{ .ARN }}"
+}
+
+resource "aws_secretsmanager_secret" "secret_{{ .Name }}" {
+	name        = "{{ .Key }}"
+	description = "Managed by Stash"
+{{if .KMSKeyID }}
+	kms_key_id = "{{ .KMSKeyID }}"
+{{end}}
+	lifecycle {
+		ignore_changes = [tags]
+	}
+}
+{{if $.Variables }}
+variable "secret_{{ .Name }}" {
+	type      = string
+	sensitive = true
+}
+
+resource "aws_secretsmanager_secret_version" "secret_{{ .Name }}" {
+	secret_id     = aws_secretsmanager_secret.secret_{{ .Name }}.id
+	secret_string = var.secret_{{ .Name }}
+}
+{{end}}{{end}}`
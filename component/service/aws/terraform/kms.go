@@ -1,37 +1,25 @@
 package terraform
 
 import (
-	"os"
+	"bytes"
 	"text/template"
 
 	"github.com/dabblebox/stash/component/service/aws/kms"
 )
 
-func EnsureTFKMSFile(filePath, kmsKeyID string) error {
-
-	if _, err := os.Stat(filePath); err != nil {
-		return CreateTFKMSFile(filePath, kmsKeyID)
-	}
-
-	return nil
-}
-
-func CreateTFKMSFile(filePath, kmsKeyID string) error {
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
+// KMS renders the KMS key and key policy.
+func KMS(kmsKeyID string) ([]byte, error) {
 	tmpl, err := template.New("kms").Parse(kms.HCLTemplate)
 	if err != nil {
-		return err
+		return []byte{}, err
 	}
-	if err := tmpl.Execute(f, kms.HCLModel{
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, kms.HCLModel{
 		KMSKeyID: kmsKeyID,
 	}); err != nil {
-		return err
+		return []byte{}, err
 	}
 
-	return nil
+	return b.Bytes(), nil
 }
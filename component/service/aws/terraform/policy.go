@@ -1,35 +1,23 @@
 package terraform
 
 import (
-	"os"
+	"bytes"
 	"text/template"
 
 	"github.com/dabblebox/stash/component/service/aws/sm"
 )
 
-func EnsureTFSMPolicyFile(filePath string) error {
-
-	if _, err := os.Stat(filePath); err != nil {
-		return CreateTFSMPolicyFile(filePath)
-	}
-
-	return nil
-}
-
-func CreateTFSMPolicyFile(filePath string) error {
-	f, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
+// SMPolicy renders the Secrets Manager resource policy.
+func SMPolicy() ([]byte, error) {
 	tmpl, err := template.New("sm").Parse(sm.HCLPolicyTemplate)
 	if err != nil {
-		return err
+		return []byte{}, err
 	}
-	if err := tmpl.Execute(f, nil); err != nil {
-		return err
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, nil); err != nil {
+		return []byte{}, err
 	}
 
-	return nil
+	return b.Bytes(), nil
 }
@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"bytes"
 	"os"
 	"strings"
 	"text/template"
@@ -18,51 +19,38 @@ type Dep struct {
 	Stderr *os.File
 }
 
-func EnsureTFVarsFile(filePath string, dep Dep) error {
-	if _, err := os.Stat(filePath); err != nil {
-
-		user, err := user.Get(user.Dep{
-			Session: dep.Session,
-			Stdin:   dep.Stdin,
-			Stdout:  dep.Stdout,
-			Stderr:  dep.Stderr,
-		})
-		if err != nil {
-			return err
-		}
-
-		return CreateTFVarsFile(filePath, user.Name)
-	}
-
-	return nil
-}
-
-func CreateTFVarsFile(filePath, userName string) error {
-
-	tmpl, err := template.New("vars").Parse(vars.HCLVarsTemplate)
+// TFVars renders the variable values granting the current user
+// access to the configuration.
+func TFVars(dep Dep) ([]byte, error) {
+	user, err := user.Get(user.Dep{
+		Session: dep.Session,
+		Stdin:   dep.Stdin,
+		Stdout:  dep.Stdout,
+		Stderr:  dep.Stderr,
+	})
 	if err != nil {
-		return err
+		return []byte{}, err
 	}
 
-	f, err := os.Create(filePath)
+	tmpl, err := template.New("vars").Parse(vars.HCLVarsTemplate)
 	if err != nil {
-		return err
+		return []byte{}, err
 	}
-	defer f.Close()
 
 	m := vars.HCLVarsModel{}
 
 	const userPrefix = "user/"
 
-	if strings.Contains(userName, userPrefix) {
-		m.IAMUsers = []string{strings.TrimPrefix(userName, userPrefix)}
+	if strings.Contains(user.Name, userPrefix) {
+		m.IAMUsers = []string{strings.TrimPrefix(user.Name, userPrefix)}
 	} else {
-		m.SAMLUsers = []string{userName}
+		m.SAMLUsers = []string{user.Name}
 	}
 
-	if err := tmpl.Execute(f, m); err != nil {
-		return err
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, m); err != nil {
+		return []byte{}, err
 	}
 
-	return nil
+	return b.Bytes(), nil
 }
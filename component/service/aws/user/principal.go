@@ -0,0 +1,90 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+// PrincipalID returns the aws:userId pattern matching requests made
+// by the IAM role or user. Role sessions are matched with a wildcard.
+func PrincipalID(principalARN string, svc *iam.IAM) (string, error) {
+	a, err := arn.Parse(principalARN)
+	if err != nil {
+		return "", err
+	}
+
+	name := a.Resource[strings.LastIndex(a.Resource, "/")+1:]
+
+	switch {
+	case strings.HasPrefix(a.Resource, "role/"):
+		o, err := svc.GetRole(&iam.GetRoleInput{RoleName: aws.String(name)})
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s:*", aws.StringValue(o.Role.RoleId)), nil
+	case strings.HasPrefix(a.Resource, "user/"):
+		o, err := svc.GetUser(&iam.GetUserInput{UserName: aws.String(name)})
+		if err != nil {
+			return "", err
+		}
+
+		return aws.StringValue(o.User.UserId), nil
+	}
+
+	return "", fmt.Errorf("%s is not an IAM role or user", principalARN)
+}
+
+// SessionARN returns the aws:arn pattern matching requests made by the
+// IAM role or user. Roles make requests through assumed role sessions.
+func SessionARN(principalARN string) (string, error) {
+	a, err := arn.Parse(principalARN)
+	if err != nil {
+		return "", err
+	}
+
+	name := a.Resource[strings.LastIndex(a.Resource, "/")+1:]
+
+	switch {
+	case strings.HasPrefix(a.Resource, "role/"):
+		return fmt.Sprintf("arn:%s:sts::%s:assumed-role/%s/*", a.Partition, a.AccountID, name), nil
+	case strings.HasPrefix(a.Resource, "user/"):
+		return principalARN, nil
+	}
+
+	return "", fmt.Errorf("%s is not an IAM role or user", principalARN)
+}
+
+// PutPolicy attaches an inline policy to the IAM role or user
+// replacing the policy with the same name.
+func PutPolicy(principalARN, name, doc string, svc *iam.IAM) error {
+	a, err := arn.Parse(principalARN)
+	if err != nil {
+		return err
+	}
+
+	principal := a.Resource[strings.LastIndex(a.Resource, "/")+1:]
+
+	switch {
+	case strings.HasPrefix(a.Resource, "role/"):
+		_, err := svc.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       aws.String(principal),
+			PolicyName:     aws.String(name),
+			PolicyDocument: aws.String(doc),
+		})
+		return err
+	case strings.HasPrefix(a.Resource, "user/"):
+		_, err := svc.PutUserPolicy(&iam.PutUserPolicyInput{
+			UserName:       aws.String(principal),
+			PolicyName:     aws.String(name),
+			PolicyDocument: aws.String(doc),
+		})
+		return err
+	}
+
+	return fmt.Errorf("%s is not an IAM role or user", principalARN)
+}
@@ -41,6 +41,13 @@ type File struct {
 
 	// Synced is the last time the file was synced with the service.
 	Synced time.Time
+
+	// Artifacts are additional generated files required by the data.
+	Artifacts []Artifact
+
+	// ARNs maps each remote key to the AWS resource ARN. Populated
+	// by downloads.
+	ARNs map[string]string
 }
 
 func toEnvVarKey(key string) string {
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/dabblebox/stash/component/service/aws/policy"
+	awsS3 "github.com/dabblebox/stash/component/service/aws/s3"
+	"github.com/dabblebox/stash/component/service/aws/user"
+)
+
+// AccessManager grants IAM roles and users read access to stashed
+// data.
+type AccessManager struct {
+	session *session.Session
+	io      IO
+}
+
+// NewAccessManager ...
+func NewAccessManager(io IO) (*AccessManager, error) {
+	sess, err := session.NewSession(&aws.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessManager{session: sess, io: io}, nil
+}
+
+// KeyARN returns the ARN of the key. IAM policies only match keys by
+// key ARN; so, aliases are resolved.
+func (a *AccessManager) KeyARN(keyID string) (string, error) {
+	o, err := kms.New(a.session).DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(tfKMSKeyID(keyID)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", keyID, err)
+	}
+
+	return aws.StringValue(o.KeyMetadata.Arn), nil
+}
+
+// Policy returns the policy reading the cataloged keys of the file.
+// ARNs are built from the keys; so, remote data is never read or
+// decrypted.
+func (a *AccessManager) Policy(serviceKey string, file File) (policy.Policy, error) {
+	if len(file.Keys) == 0 {
+		return policy.Policy{}, errors.New("keys are empty, the file has not been synced")
+	}
+
+	if serviceKey == "s3" {
+		bucket := file.Options[S3BucketOption]
+		if len(bucket) == 0 {
+			return policy.Policy{}, fmt.Errorf("%s required", S3BucketOption)
+		}
+
+		file.RemoteKey = objectKey(file)
+
+		return S3Service{}.access(file, bucket).Policy, nil
+	}
+
+	caller, err := user.Get(user.Dep{
+		Session: a.session,
+		Stdin:   a.io.Stdin,
+		Stdout:  a.io.Stdout,
+		Stderr:  a.io.Stderr,
+	})
+	if err != nil {
+		return policy.Policy{}, err
+	}
+
+	region := aws.StringValue(a.session.Config.Region)
+
+	arns := []string{}
+
+	switch serviceKey {
+	case "parameter-store":
+		for _, k := range file.Keys {
+			arns = append(arns, fmt.Sprintf("arn:aws:ssm:%s:%s:parameter/%s", region, caller.AccountID, strings.TrimLeft(k, "/")))
+		}
+
+		// Parameters are read by path.
+		arns = append(arns, path.Dir(arns[0]))
+
+		return policy.New(policy.Statement{
+			Effect:   "Allow",
+			Action:   []string{"ssm:GetParameters", "ssm:GetParametersByPath"},
+			Resource: &arns,
+		}), nil
+	case "secrets-manager":
+		// Secret ARNs end with six random characters.
+		for _, k := range file.Keys {
+			arns = append(arns, fmt.Sprintf("arn:aws:secretsmanager:%s:%s:secret:%s-??????", region, caller.AccountID, k))
+		}
+
+		return policy.New(policy.Statement{
+			Effect:   "Allow",
+			Action:   []string{"secretsmanager:GetSecretValue"},
+			Resource: &arns,
+		}), nil
+	}
+
+	return policy.Policy{}, fmt.Errorf("service %s not found ", serviceKey)
+}
+
+// Attach adds the policy to the IAM role or user as an inline policy.
+// An existing inline policy with the same name is replaced.
+func (a *AccessManager) Attach(principalARN, name string, p policy.Policy) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return user.PutPolicy(principalARN, name, string(b), iam.New(a.session))
+}
+
+// GrantBucket adds the IAM role or user to the readers of a bucket
+// policy created by Stash. False is returned when the principal
+// already has access.
+func (a *AccessManager) GrantBucket(bucket, principalARN string) (bool, error) {
+	reader, err := user.SessionARN(principalARN)
+	if err != nil {
+		return false, err
+	}
+
+	svc := s3.New(a.session)
+
+	doc, err := awsS3.GetPolicy(bucket, svc)
+	if err != nil {
+		return false, err
+	}
+
+	updated, changed, err := awsS3.UpdateReaders(doc, reader, true)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	return true, awsS3.PutPolicy(bucket, updated, svc)
+}
@@ -39,6 +39,15 @@ type IService interface {
 
 	// Purge deletes file contents from the remote service.
 	Purge(file File) error
+
+	// List returns the remote objects stored under the file's
+	// remote key used as a prefix. When tags are specified,
+	// only objects with matching tags are returned.
+	List(file File, tags map[string]string) ([]Object, error)
+
+	// Import groups remote objects into files that can be
+	// cataloged. Remote objects are never modified.
+	Import(context string, objects []Object) ([]File, error)
 }
 
 // IO ...
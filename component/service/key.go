@@ -9,3 +9,11 @@ import (
 func FormatObjectKey(context, path string, service IService) string {
 	return service.ObjectKey(fmt.Sprintf("%s/%s", context, strings.TrimLeft(path, "./")))
 }
+
+// trimContext converts a remote object key back into a local path
+// by removing the context prefix.
+func trimContext(context, key string) string {
+	key = strings.TrimLeft(key, "/")
+
+	return strings.TrimPrefix(key, fmt.Sprintf("%s/", context))
+}
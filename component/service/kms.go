@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	awskms "github.com/dabblebox/stash/component/service/aws/kms"
+	"github.com/dabblebox/stash/component/service/aws/user"
+)
+
+// KeyManager manages the KMS keys encrypting stashed data.
+type KeyManager struct {
+	session *session.Session
+	io      IO
+}
+
+// NewKeyManager ...
+func NewKeyManager(io IO) (*KeyManager, error) {
+	sess, err := session.NewSession(&aws.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyManager{session: sess, io: io}, nil
+}
+
+// DefaultKMSKeyID returns the AWS managed key used by the service when
+// a file does not specify a key.
+func DefaultKMSKeyID(serviceKey string) string {
+	switch serviceKey {
+	case "secrets-manager":
+		return SMKMSKeyIDDefault
+	case "parameter-store":
+		return PSKMSKeyIDDefault
+	case "s3":
+		return S3KMSKeyIDDefault
+	}
+
+	return ""
+}
+
+// KMSKeyID returns the key encrypting the file.
+func KMSKeyID(serviceKey string, options map[string]string) string {
+	if k := options[KMSKeyIDOption]; len(k) > 0 {
+		return k
+	}
+
+	return DefaultKMSKeyID(serviceKey)
+}
+
+// IsAWSManagedKey determines if the key policy is managed by AWS and
+// cannot be changed.
+func IsAWSManagedKey(keyID string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(keyID, "alias/"), "aws/")
+}
+
+// Create creates a key with an alias. Admins can manage and use the
+// key while users can only decrypt. The current user is always an
+// admin.
+func (k *KeyManager) Create(alias string, admins, users []string) (string, error) {
+	caller, err := user.Get(user.Dep{
+		Session: k.session,
+		Stdin:   k.io.Stdin,
+		Stdout:  k.io.Stdout,
+		Stderr:  k.io.Stderr,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	adminIDs := []string{caller.ID}
+	for _, a := range admins {
+		p, err := k.Principal(a)
+		if err != nil {
+			return "", err
+		}
+
+		adminIDs = append(adminIDs, p.ID)
+	}
+
+	userIDs := []string{}
+	for _, u := range users {
+		p, err := k.Principal(u)
+		if err != nil {
+			return "", err
+		}
+
+		userIDs = append(userIDs, p.ID)
+	}
+
+	if !strings.HasPrefix(alias, "alias/") {
+		alias = fmt.Sprintf("alias/%s", alias)
+	}
+
+	return awskms.CreateKey("Created by Stash", alias, awskms.Policy(adminIDs, userIDs, []string{}, caller.AccountID), map[string]string{}, kms.New(k.session))
+}
+
+// Principal looks up the IAM role or user.
+func (k *KeyManager) Principal(principalARN string) (awskms.Principal, error) {
+	id, err := user.PrincipalID(principalARN, iam.New(k.session))
+	if err != nil {
+		return awskms.Principal{}, fmt.Errorf("%s: %s", principalARN, err)
+	}
+
+	return awskms.Principal{ARN: principalARN, ID: id}, nil
+}
+
+// Grant allows the principal to decrypt data encrypted by the key.
+// False is returned when the principal already has access.
+func (k *KeyManager) Grant(keyID string, p awskms.Principal) (bool, error) {
+	return k.updateReaders(keyID, p, true)
+}
+
+// Revoke removes decrypt access granted to the principal. False is
+// returned when the principal was not granted access.
+func (k *KeyManager) Revoke(keyID string, p awskms.Principal) (bool, error) {
+	return k.updateReaders(keyID, p, false)
+}
+
+func (k *KeyManager) updateReaders(keyID string, p awskms.Principal, grant bool) (bool, error) {
+	if IsAWSManagedKey(keyID) {
+		return false, fmt.Errorf("%s is an AWS managed key, use IAM policies instead", keyID)
+	}
+
+	svc := kms.New(k.session)
+
+	doc, err := awskms.GetPolicy(keyID, svc)
+	if err != nil {
+		return false, err
+	}
+
+	updated, changed, err := awskms.UpdateReaders(doc, p, grant)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	return true, awskms.PutPolicy(keyID, updated, svc)
+}
+
+// Resolve returns the key id for a key id, ARN, or alias. AWS managed
+// keys are returned unchanged since services refer to them by name.
+func (k *KeyManager) Resolve(keyID string) (string, error) {
+	if IsAWSManagedKey(keyID) {
+		return strings.TrimPrefix(keyID, "alias/"), nil
+	}
+
+	o, err := kms.New(k.session).DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", keyID, err)
+	}
+
+	return aws.StringValue(o.KeyMetadata.KeyId), nil
+}
+
+// Aliases lists the aliases referring to the key.
+func (k *KeyManager) Aliases(keyID string) ([]string, error) {
+	aliases := []string{}
+
+	if IsAWSManagedKey(keyID) {
+		return aliases, nil
+	}
+
+	o, err := kms.New(k.session).ListAliases(&kms.ListAliasesInput{
+		KeyId: aws.String(keyID),
+	})
+	if err != nil {
+		return aliases, err
+	}
+
+	for _, a := range o.Aliases {
+		aliases = append(aliases, aws.StringValue(a.AliasName))
+	}
+
+	return aliases, nil
+}
+
+// KeyID returns the key id for a key id, ARN, or alias. Unlike Resolve,
+// AWS managed keys are resolved; so, keys can be compared.
+func (k *KeyManager) KeyID(keyID string) (string, error) {
+	o, err := kms.New(k.session).DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(tfKMSKeyID(keyID)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", keyID, err)
+	}
+
+	return aws.StringValue(o.KeyMetadata.KeyId), nil
+}
+
+// ObjectKMSKeyID returns the key encrypting the remote object. Secrets
+// encrypted with the default key do not report a key.
+func ObjectKMSKeyID(serviceKey string, o Object) string {
+	if len(o.KMSKeyID) == 0 && serviceKey == "secrets-manager" {
+		return SMKMSKeyIDDefault
+	}
+
+	return o.KMSKeyID
+}
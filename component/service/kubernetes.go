@@ -0,0 +1,32 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/dabblebox/stash/component/output"
+)
+
+const (
+	// K8sSecretStoreOption is the External Secrets Operator store
+	// referenced by the k8s-external-secret output.
+	K8sSecretStoreOption = "k8s_secret_store"
+
+	// K8sSecretStoreKindOption is the kind of store referenced.
+	K8sSecretStoreKindOption = "k8s_secret_store_kind"
+
+	K8sSecretStoreKindDefault = "SecretStore"
+	K8sClusterSecretStoreKind = "ClusterSecretStore"
+)
+
+// k8sSecretStore returns the store configured for the file.
+func k8sSecretStore(file File, defaultName string) output.SecretStore {
+	kind := K8sSecretStoreKindDefault
+	if strings.EqualFold(file.Options[K8sSecretStoreKindOption], K8sClusterSecretStoreKind) {
+		kind = K8sClusterSecretStoreKind
+	}
+
+	return output.SecretStore{
+		Name: optionDefault(file, K8sSecretStoreOption, defaultName),
+		Kind: kind,
+	}
+}
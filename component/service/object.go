@@ -0,0 +1,53 @@
+package service
+
+import (
+	"sort"
+	"time"
+)
+
+// Object is a single remote object such as a secret, a parameter,
+// or an S3 object.
+type Object struct {
+	// Key is the remote service key.
+	Key string
+
+	// ARN is the AWS resource name when available.
+	ARN string
+
+	// Type is the remote object type when the service supports
+	// more than one. (e.g. SecureString)
+	Type string
+
+	// KMSKeyID is the KMS key used to encrypt the object.
+	KMSKeyID string
+
+	// Tags are the remote resource tags.
+	Tags map[string]string
+
+	// LastModified is the last time the object changed.
+	LastModified time.Time
+}
+
+// MatchesTags determines if the object has all the specified tags.
+func (o Object) MatchesTags(tags map[string]string) bool {
+	for k, v := range tags {
+		if o.Tags[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ByKey ...
+type ByKey []Object
+
+func (o ByKey) Len() int           { return len(o) }
+func (o ByKey) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
+func (o ByKey) Less(i, j int) bool { return o[i].Key < o[j].Key }
+
+func sortObjects(objects []Object) []Object {
+	sort.Sort(ByKey(objects))
+
+	return objects
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dabblebox/stash/component/slice"
+)
+
+// sharedOptions are read by every service.
+var sharedOptions = []string{
+	KMSKeyIDOption,
+	TFValuesOption,
+	K8sSecretStoreOption,
+	K8sSecretStoreKindOption,
+}
+
+// serviceOptions are the options read by each service.
+var serviceOptions = map[string][]string{
+	"secrets-manager": {
+		SMSecretsOption,
+		SMDelimiterOption,
+	},
+	"parameter-store": {
+		PSTypeOption,
+		PSTypesOption,
+		PSTierOption,
+		PSExpirationOption,
+		PSExpirationNotificationOption,
+		PSNoChangeNotificationOption,
+		PSTagsOption,
+	},
+	"s3": {
+		S3BucketOption,
+		S3RoleOption,
+		arnsOption,
+	},
+}
+
+// requiredOptions must be set once a file is synced.
+var requiredOptions = map[string][]string{
+	"s3": {S3BucketOption},
+}
+
+// optionValues are the values accepted by options with a fixed set
+// of values.
+var optionValues = map[string][]string{
+	SMSecretsOption:          SMSecretsOptions,
+	PSTypeOption:             PSTypeOptions,
+	PSTierOption:             PSTierOptions,
+	TFValuesOption:           TFValuesOptions,
+	K8sSecretStoreKindOption: {K8sSecretStoreKindDefault, K8sClusterSecretStoreKind},
+}
+
+// ValidateOptions checks the options are read by the service and hold
+// accepted values.
+func ValidateOptions(serviceKey string, options map[string]string, synced bool) []error {
+	errs := []error{}
+
+	names := []string{}
+	for k := range options {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		v := options[k]
+
+		if !slice.In(k, sharedOptions) && !slice.In(k, serviceOptions[serviceKey]) {
+			errs = append(errs, fmt.Errorf("option %s is not supported by %s", k, serviceKey))
+			continue
+		}
+
+		if values, ok := optionValues[k]; ok && len(v) > 0 && !slice.In(v, values) {
+			errs = append(errs, fmt.Errorf("option %s value %s is not one of %v", k, v, values))
+		}
+
+		switch k {
+		case PSExpirationOption:
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				errs = append(errs, fmt.Errorf("option %s must be an RFC3339 timestamp", k))
+			}
+		case PSExpirationNotificationOption, PSNoChangeNotificationOption:
+			if days, err := strconv.Atoi(v); err != nil || days < 1 {
+				errs = append(errs, fmt.Errorf("option %s must be a number of days", k))
+			}
+		}
+	}
+
+	if synced {
+		for _, k := range requiredOptions[serviceKey] {
+			if len(options[k]) == 0 {
+				errs = append(errs, fmt.Errorf("option %s is required by %s", k, serviceKey))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Options returns the options read by the service.
+func Options(serviceKey string) []string {
+	options := append([]string{}, sharedOptions...)
+	options = append(options, serviceOptions[serviceKey]...)
+
+	sort.Strings(options)
+
+	return options
+}
+
+// OptionValues returns the values accepted by an option with a fixed
+// set of values.
+func OptionValues(option string) ([]string, bool) {
+	values, ok := optionValues[option]
+
+	return values, ok
+}
+
+// RequiredOptions returns the options required by the service once a
+// file is synced.
+func RequiredOptions(serviceKey string) []string {
+	return requiredOptions[serviceKey]
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -21,8 +23,10 @@ import (
 	"github.com/dabblebox/stash/component/file"
 	"github.com/dabblebox/stash/component/format"
 	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service/aws/iac"
 	awskms "github.com/dabblebox/stash/component/service/aws/kms"
 	"github.com/dabblebox/stash/component/service/aws/policy"
+	"github.com/dabblebox/stash/component/service/aws/ps"
 	"github.com/dabblebox/stash/component/service/aws/role"
 	"github.com/dabblebox/stash/component/service/aws/terraform"
 	"github.com/dabblebox/stash/component/service/aws/user"
@@ -31,8 +35,43 @@ import (
 
 const (
 	PSKMSKeyIDDefault = "aws/ssm"
+
+	PSSecretStoreDefault = "aws-parameter-store"
+
+	PSTypeOption  = "param_type"
+	PSTypesOption = "param_types"
+	PSTypeDefault = ssm.ParameterTypeSecureString
+
+	PSTierOption  = "param_tier"
+	PSTierDefault = ssm.ParameterTierStandard
+
+	PSExpirationOption             = "param_expiration"
+	PSExpirationNotificationOption = "param_expiration_notification"
+	PSNoChangeNotificationOption   = "param_no_change_notification"
+
+	PSTagsOption = "param_tags"
+
+	// psManagedTagsKey lists the tag keys applied from param_tags; so,
+	// only those tags are removed when they are no longer listed.
+	psManagedTagsKey = "stash:tags"
+
+	// psStandardTierMaxSize is the largest value in bytes a standard
+	// tier parameter can hold.
+	psStandardTierMaxSize = 4096
 )
 
+var PSTypeOptions = []string{
+	ssm.ParameterTypeSecureString,
+	ssm.ParameterTypeString,
+	ssm.ParameterTypeStringList,
+}
+
+var PSTierOptions = []string{
+	ssm.ParameterTierStandard,
+	ssm.ParameterTierAdvanced,
+	ssm.ParameterTierIntelligentTiering,
+}
+
 // ParameterStoreService ...
 type ParameterStoreService struct {
 	session *session.Session
@@ -110,11 +149,30 @@ func (s ParameterStoreService) Sync(file File) (File, error) {
 		return file, err
 	}
 
-	if err := file.EnsureOption(Opt{
-		Key:          KMSKeyIDOption,
-		DefaultValue: PSKMSKeyIDDefault,
-		Description:  awskms.Prompt}, s.io); err != nil {
-		return file, err
+	localParams := map[string]param{}
+
+	for name, value := range params {
+
+		remoteKey, found := file.LookupRemoteKey(name)
+		if !found {
+			remoteKey = fmt.Sprintf("%s/%s", file.RemoteKey, name)
+		}
+
+		p, err := toParam(remoteKey, value, file)
+		if err != nil {
+			return file, err
+		}
+
+		localParams[name] = p
+	}
+
+	if requiresKMS(localParams) {
+		if err := file.EnsureOption(Opt{
+			Key:          KMSKeyIDOption,
+			DefaultValue: PSKMSKeyIDDefault,
+			Description:  awskms.Prompt}, s.io); err != nil {
+			return file, err
+		}
 	}
 
 	keyID := file.Options[KMSKeyIDOption]
@@ -144,9 +202,13 @@ func (s ParameterStoreService) Sync(file File) (File, error) {
 
 	remoteParams := map[string]param{}
 
+	// Tags are only read when tagging; each read is a call per
+	// parameter.
+	tags := parseParamPairs(file.Options[PSTagsOption])
+
 	for remoteKeyPath, trackedProps := range getKeyPaths(file) {
 
-		rps, err := getRemoteParamsWithMetaData(remoteKeyPath, trackedProps, svc)
+		rps, err := getRemoteParamsWithMetaData(remoteKeyPath, trackedProps, len(tags) > 0, svc)
 		if err != nil {
 			return file, err
 		}
@@ -159,18 +221,12 @@ func (s ParameterStoreService) Sync(file File) (File, error) {
 	modifiedParams := []param{}
 	unsyncedParams := map[string]time.Time{}
 
-	for name, value := range params {
+	for _, param := range localParams {
 
-		remoteKey, found := file.LookupRemoteKey(name)
-		if !found {
-			remoteKey = fmt.Sprintf("%s/%s", file.RemoteKey, name)
+		if param.pType == ssm.ParameterTypeSecureString {
+			param.keyID = file.Options[KMSKeyIDOption]
 		}
 
-		param := toParam(
-			remoteKey,
-			value,
-			file.Options[KMSKeyIDOption])
-
 		if remoteParam, ok := remoteParams[param.name]; ok {
 			if param.Changed(remoteParam) {
 				modifiedParams = append(modifiedParams, param)
@@ -203,20 +259,43 @@ func (s ParameterStoreService) Sync(file File) (File, error) {
 	//- Update Modified Parameters
 	//------------------------------------------
 	for _, param := range modifiedParams {
-		_, err := svc.PutParameter(&ssm.PutParameterInput{
+		input := &ssm.PutParameterInput{
 			Name:      &param.name,
 			Value:     &param.value,
 			Overwrite: aws.Bool(true),
-			Type:      aws.String(ssm.ParameterTypeSecureString),
-			KeyId:     nilDefault(param.keyID, PSKMSKeyIDDefault),
-		})
-		if err != nil {
+			Type:      aws.String(param.pType),
+			Tier:      aws.String(param.tier),
+		}
+
+		if param.pType == ssm.ParameterTypeSecureString {
+			input.KeyId = nilDefault(param.keyID, PSKMSKeyIDDefault)
+		}
+
+		if len(param.policies) > 0 {
+			input.Policies = aws.String(fmt.Sprintf("[%s]", strings.Join(param.policies, ",")))
+		} else if len(remoteParams[param.name].policies) > 0 {
+			// Removed expiration options remove the remote policies.
+			input.Policies = aws.String("[]")
+		}
+
+		if _, err := svc.PutParameter(input); err != nil {
 			return file, fmt.Errorf("%s: %s", param.name, err)
 		}
 
 		file.AddKey(param.name)
 	}
 
+	//------------------------------------------
+	//- Tag Parameters
+	//------------------------------------------
+	if len(tags) > 0 {
+		for _, param := range localParams {
+			if err := tagParam(param.name, tags, remoteParams[param.name].tags, svc); err != nil {
+				return file, fmt.Errorf("%s: %s", param.name, err)
+			}
+		}
+	}
+
 	//------------------------------------------
 	//- Delete Removed Parameters
 	//------------------------------------------
@@ -235,16 +314,271 @@ func (s ParameterStoreService) Sync(file File) (File, error) {
 	return file, nil
 }
 
-func toParam(name, value, keyID string) param {
+func toParam(name, value string, file File) (param, error) {
+	pType, err := paramType(filepath.Base(name), file)
+	if err != nil {
+		return param{}, err
+	}
+
+	policies, err := paramPolicies(file)
+	if err != nil {
+		return param{}, err
+	}
+
+	tier, err := paramTier(value, policies, file)
+	if err != nil {
+		return param{}, err
+	}
+
 	return param{
-		name:  name,
-		value: value,
-		keyID: keyID,
-		pType: ssm.ParameterTypeSecureString,
+		name:     name,
+		value:    value,
+		pType:    pType,
+		tier:     tier,
+		policies: policies,
+	}, nil
+}
+
+// paramType resolves the parameter type from the per key types
+// falling back to the file type.
+// i.e. param_types: API_URL=String,HOSTS=StringList
+func paramType(name string, file File) (string, error) {
+	pType := optionDefault(file, PSTypeOption, PSTypeDefault)
+
+	if t, ok := parseParamPairs(file.Options[PSTypesOption])[name]; ok {
+		pType = t
 	}
+
+	for _, t := range PSTypeOptions {
+		if strings.EqualFold(t, pType) {
+			return t, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s: invalid %s %s, use %s", name, PSTypeOption, pType, strings.Join(PSTypeOptions, ", "))
+}
+
+// paramTier resolves the parameter tier. Values too large for the
+// standard tier and parameters with policies require the advanced
+// tier.
+func paramTier(value string, policies []string, file File) (string, error) {
+	tier := optionDefault(file, PSTierOption, PSTierDefault)
+
+	valid := false
+	for _, t := range PSTierOptions {
+		if strings.EqualFold(t, tier) {
+			tier = t
+			valid = true
+		}
+	}
+
+	if !valid {
+		return "", fmt.Errorf("invalid %s %s, use %s", PSTierOption, tier, strings.Join(PSTierOptions, ", "))
+	}
+
+	if tier == ssm.ParameterTierStandard && (len(value) > psStandardTierMaxSize || len(policies) > 0) {
+		return ssm.ParameterTierAdvanced, nil
+	}
+
+	return tier, nil
+}
+
+type paramPolicy struct {
+	Type       string            `json:"Type"`
+	Version    string            `json:"Version"`
+	Attributes map[string]string `json:"Attributes"`
 }
 
-func getRemoteParamsWithMetaData(remoteKey string, props []string, svc *ssm.SSM) (map[string]param, error) {
+// paramPolicies builds the parameter policies from the file options.
+// i.e. param_expiration: 2020-12-31T00:00:00Z, param_no_change_notification: 30
+func paramPolicies(file File) ([]string, error) {
+	policies := []paramPolicy{}
+
+	if v, ok := file.Options[PSExpirationOption]; ok && len(v) > 0 {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return []string{}, fmt.Errorf("invalid %s %s: %s", PSExpirationOption, v, err)
+		}
+
+		policies = append(policies, paramPolicy{
+			Type:       "Expiration",
+			Version:    "1.0",
+			Attributes: map[string]string{"Timestamp": t.UTC().Format("2006-01-02T15:04:05.000Z")},
+		})
+	}
+
+	if v, ok := file.Options[PSExpirationNotificationOption]; ok && len(v) > 0 {
+		if _, err := strconv.Atoi(v); err != nil {
+			return []string{}, fmt.Errorf("invalid %s %s: days required", PSExpirationNotificationOption, v)
+		}
+
+		policies = append(policies, paramPolicy{
+			Type:       "ExpirationNotification",
+			Version:    "1.0",
+			Attributes: map[string]string{"Before": v, "Unit": "Days"},
+		})
+	}
+
+	if v, ok := file.Options[PSNoChangeNotificationOption]; ok && len(v) > 0 {
+		if _, err := strconv.Atoi(v); err != nil {
+			return []string{}, fmt.Errorf("invalid %s %s: days required", PSNoChangeNotificationOption, v)
+		}
+
+		policies = append(policies, paramPolicy{
+			Type:       "NoChangeNotification",
+			Version:    "1.0",
+			Attributes: map[string]string{"After": v, "Unit": "Days"},
+		})
+	}
+
+	texts := []string{}
+	for _, p := range policies {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return []string{}, err
+		}
+
+		texts = append(texts, string(b))
+	}
+
+	return texts, nil
+}
+
+// normalizePolicies formats remote policy text the same as local
+// policies to allow comparing them.
+func normalizePolicies(inline []*ssm.ParameterInlinePolicy) []string {
+	texts := []string{}
+
+	for _, ip := range inline {
+		if ip.PolicyText == nil {
+			continue
+		}
+
+		p := paramPolicy{}
+		if err := json.Unmarshal([]byte(*ip.PolicyText), &p); err != nil {
+			texts = append(texts, *ip.PolicyText)
+			continue
+		}
+
+		b, err := json.Marshal(p)
+		if err != nil {
+			texts = append(texts, *ip.PolicyText)
+			continue
+		}
+
+		texts = append(texts, string(b))
+	}
+
+	sort.Strings(texts)
+
+	return texts
+}
+
+func requiresKMS(params map[string]param) bool {
+	for _, p := range params {
+		if p.pType == ssm.ParameterTypeSecureString {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tagParam adds the tags missing or different on the parameter and
+// removes the tags applied by an earlier sync that are no longer
+// listed. Tags applied by other tools are left untouched.
+func tagParam(name string, tags, remoteTags map[string]string, svc *ssm.SSM) error {
+	keys := []string{}
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	applied := map[string]string{psManagedTagsKey: strings.Join(keys, " ")}
+	for k, v := range tags {
+		applied[k] = v
+	}
+
+	awsTags := []*ssm.Tag{}
+	for k, v := range applied {
+		if rv, ok := remoteTags[k]; ok && rv == v {
+			continue
+		}
+
+		awsTags = append(awsTags, &ssm.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	if len(awsTags) > 0 {
+		if _, err := svc.AddTagsToResource(&ssm.AddTagsToResourceInput{
+			ResourceId:   aws.String(name),
+			ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+			Tags:         awsTags,
+		}); err != nil {
+			return err
+		}
+	}
+
+	removed := []*string{}
+	for _, k := range strings.Fields(remoteTags[psManagedTagsKey]) {
+		if _, ok := tags[k]; !ok {
+			removed = append(removed, aws.String(k))
+		}
+	}
+
+	if len(removed) > 0 {
+		if _, err := svc.RemoveTagsFromResource(&ssm.RemoveTagsFromResourceInput{
+			ResourceId:   aws.String(name),
+			ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+			TagKeys:      removed,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseParamPairs parses key/value pairs separated by new lines or
+// commas. (e.g. team=devops,env=dev)
+func parseParamPairs(input string) map[string]string {
+	pairs := map[string]string{}
+
+	lines := strings.FieldsFunc(input, func(r rune) bool {
+		return r == '\n' || r == ','
+	})
+	for _, l := range lines {
+		pair := strings.SplitN(l, "=", 2)
+		if len(pair) < 2 {
+			continue
+		}
+
+		pairs[strings.TrimSpace(pair[0])] = strings.TrimSpace(pair[1])
+	}
+
+	return pairs
+}
+
+func getParamTags(svc *ssm.SSM, name string) (map[string]string, error) {
+	output, err := svc.ListTagsForResource(&ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String(name),
+		ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	for _, t := range output.TagList {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return tags, nil
+}
+
+func getRemoteParamsWithMetaData(remoteKey string, props []string, withTags bool, svc *ssm.SSM) (map[string]param, error) {
 
 	parameters := map[string]param{}
 
@@ -260,7 +594,17 @@ func getRemoteParamsWithMetaData(remoteKey string, props []string, svc *ssm.SSM)
 				return parameters, err
 			}
 
-			sp.keyID = findKMSKeyID(history)
+			if latest := findLatest(history); latest != nil {
+				sp.keyID = strings.Replace(aws.StringValue(latest.KeyId), "alias/", "", 1)
+				sp.tier = aws.StringValue(latest.Tier)
+				sp.policies = normalizePolicies(latest.Policies)
+			}
+
+			if withTags {
+				if sp.tags, err = getParamTags(svc, sp.name); err != nil {
+					return parameters, err
+				}
+			}
 
 			parameters[sp.name] = sp
 		}
@@ -269,19 +613,19 @@ func getRemoteParamsWithMetaData(remoteKey string, props []string, svc *ssm.SSM)
 	return parameters, nil
 }
 
-func findKMSKeyID(history []*ssm.ParameterHistory) (keyID string) {
+func findLatest(history []*ssm.ParameterHistory) (latest *ssm.ParameterHistory) {
 
 	var maxVersion int64 = 0
 
 	for _, ph := range history {
 
-		if ph.Version != nil && ph.KeyId != nil && (*ph.Version) > maxVersion {
+		if ph.Version != nil && (*ph.Version) > maxVersion {
 			maxVersion = *ph.Version
-			keyID = strings.Replace(*ph.KeyId, "alias/", "", 1)
+			latest = ph
 		}
 	}
 
-	return keyID
+	return latest
 }
 
 // The AWS api call, GetParameterHistory, does NOT make a call for every individual page in Parameter Store like the call, DescribeParameters, does.
@@ -391,37 +735,89 @@ func (s ParameterStoreService) Download(file File, format string) (File, error)
 
 	paramMap := toParamMap(remoteParams)
 
+	file.ARNs = map[string]string{}
+	for _, p := range remoteParams {
+		file.ARNs[p.name] = p.arn
+	}
+
 	switch format {
 	case output.TypeTerraform:
-		dir := filepath.Dir(file.LocalPath)
-
-		filePath := fmt.Sprintf("%s/kms.tf", dir)
-		printFile(filePath, s.io.Stderr)
+		params, err := toParams(paramMap, file)
+		if err != nil {
+			return file, err
+		}
 
-		if err := terraform.EnsureTFKMSFile(filePath, blankDefault(file.Options[KMSKeyIDOption], PSKMSKeyIDDefault)); err != nil {
+		artifacts, err := tfArtifacts(requiresKMS(params), blankDefault(file.Options[KMSKeyIDOption], PSKMSKeyIDDefault), terraform.Dep{
+			Session: s.session,
+			Stdin:   s.io.Stdin,
+			Stdout:  s.io.Stdout,
+			Stderr:  s.io.Stderr,
+		})
+		if err != nil {
 			return file, err
 		}
 
-		filePath = fmt.Sprintf("%s/%s.variables.tf", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
+		file.Artifacts = artifacts
 
-		if err := terraform.EnsureTFVariablesFile(filePath); err != nil {
+		d, err := s.terraform(s.access(paramMap, params, file))
+		file.Data = d
+		return file, err
+	case output.TypeTerraformResources:
+		params, err := toParams(paramMap, file)
+		if err != nil {
 			return file, err
 		}
 
-		filePath = fmt.Sprintf("%s/%s.auto.tfvars", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
-
-		if err := terraform.EnsureTFVarsFile(filePath, terraform.Dep{
-			Session: s.session,
-			Stdin:   s.io.Stdin,
-			Stdout:  s.io.Stdout,
-			Stderr:  s.io.Stderr,
-		}); err != nil {
+		d, err := s.terraformResources(params, file)
+		file.Data = d
+		return file, err
+	case output.TypeCloudFormation, output.TypePulumi, output.TypeIAMPolicy:
+		params, err := toParams(paramMap, file)
+		if err != nil {
 			return file, err
 		}
 
-		d, err := s.terraform(paramMap, file, s.io)
+		d, err := renderIaC(format, s.access(paramMap, params, file), file)
+		file.Data = d
+		return file, err
+	case output.TypeECSContainerDef:
+		def := output.ECSContainerDefinition{}
+
+		// Only secure strings are injected as secrets.
+		for _, p := range remoteParams {
+			if p.pType == ssm.ParameterTypeSecureString {
+				def.Secrets = append(def.Secrets, output.ECSSecret{
+					Name:      filepath.Base(p.name),
+					ValueFrom: p.arn,
+				})
+				continue
+			}
+
+			def.Environment = append(def.Environment, output.ECSKeyValue{
+				Name:  filepath.Base(p.name),
+				Value: p.value,
+			})
+		}
+
+		d, err := def.Marshal()
+		file.Data = d
+		return file, err
+	case output.TypeK8sExternalSecret:
+		names := []string{}
+		for name := range paramMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		refs := []output.ExternalSecretRef{}
+		for _, name := range names {
+			refs = append(refs, output.ExternalSecretRef{
+				SecretKey: filepath.Base(name),
+				Key:       name,
+			})
+		}
+
+		d, err := output.ExternalSecret(output.KubernetesName(file.LocalPath), k8sSecretStore(file, PSSecretStoreDefault), refs)
 		file.Data = d
 		return file, err
 	case output.TypeECSTaskInjectJson:
@@ -451,25 +847,74 @@ func (s ParameterStoreService) Download(file File, format string) (File, error)
 	return file, nil
 }
 
-func (s ParameterStoreService) terraform(m map[string]value, file File, io IO) ([]byte, error) {
+func toParams(m map[string]value, file File) (map[string]param, error) {
+	params := map[string]param{}
 
-	var hcl bytes.Buffer
-	w := bufio.NewWriter(&hcl)
+	for name, value := range m {
+		p, err := toParam(name, value.Value, file)
+		if err != nil {
+			return params, err
+		}
+
+		params[name] = p
+	}
+
+	return params, nil
+}
+
+// access collects the infrastructure data for the parameters.
+func (s ParameterStoreService) access(m map[string]value, params map[string]param, file File) access {
+
+	names := []string{}
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	a := access{
+		Name: format.TerraformResourceName(file.RemoteKey),
+	}
 
 	arns := []string{}
-	for _, value := range m {
-		arns = append(arns, value.ARN)
+
+	for _, name := range names {
+		p := params[name]
+
+		keyID := ""
+		if p.pType == ssm.ParameterTypeSecureString {
+			keyID = tfKMSKeyID(optionDefault(file, KMSKeyIDOption, PSKMSKeyIDDefault))
+		}
+
+		a.Parameters = append(a.Parameters, iac.Resource{
+			Name:     format.TerraformResourceName(name),
+			Key:      name,
+			ARN:      m[name].ARN,
+			Type:     p.pType,
+			KMSKeyID: keyID,
+		})
+
+		arns = append(arns, m[name].ARN)
 	}
 
 	if len(arns) > 0 {
 		arns = append(arns, filepath.Dir(arns[0]))
 	}
 
-	p, err := json.MarshalIndent(policy.New(policy.Statement{
+	a.Policy = policy.New(policy.Statement{
 		Effect:   "Allow",
 		Action:   []string{"ssm:GetParameters", "ssm:GetParametersByPath"},
 		Resource: &arns,
-	}), "", "    ")
+	})
+
+	return a
+}
+
+func (s ParameterStoreService) terraform(a access) ([]byte, error) {
+
+	var hcl bytes.Buffer
+	w := bufio.NewWriter(&hcl)
+
+	p, err := json.MarshalIndent(a.Policy, "", "    ")
 	if err != nil {
 		return []byte{}, err
 	}
@@ -480,7 +925,7 @@ func (s ParameterStoreService) terraform(m map[string]value, file File, io IO) (
 	}
 
 	if err := tmpl.Execute(w, role.HCLModel{
-		Name:   format.TerraformResourceName(filepath.Base(file.RemoteKey)),
+		Name:   a.Name,
 		Policy: string(p),
 	}); err != nil {
 		return []byte{}, err
@@ -491,6 +936,50 @@ func (s ParameterStoreService) terraform(m map[string]value, file File, io IO) (
 	return hcl.Bytes(), nil
 }
 
+func (s ParameterStoreService) terraformResources(params map[string]param, file File) ([]byte, error) {
+
+	names := []string{}
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	model := ps.HCLResourcesModel{
+		Variables: tfVariables(file),
+	}
+
+	for _, name := range names {
+		p := params[name]
+
+		keyID := ""
+		if p.pType == ssm.ParameterTypeSecureString {
+			keyID = tfKMSKeyID(optionDefault(file, KMSKeyIDOption, PSKMSKeyIDDefault))
+		}
+
+		model.Parameters = append(model.Parameters, ps.HCLParameter{
+			Name:     format.TerraformResourceName(p.name),
+			Key:      p.name,
+			Type:     p.pType,
+			Tier:     p.tier,
+			KMSKeyID: keyID,
+			Policies: p.policies,
+			Tags:     parseParamPairs(file.Options[PSTagsOption]),
+		})
+	}
+
+	tmpl, err := template.New("ps").Parse(ps.HCLResourcesTemplate)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var hcl bytes.Buffer
+	if err := tmpl.Execute(&hcl, model); err != nil {
+		return []byte{}, err
+	}
+
+	return hcl.Bytes(), nil
+}
+
 func toParamMap(params []param) map[string]value {
 	data := map[string]value{}
 
@@ -562,6 +1051,147 @@ func (s ParameterStoreService) Purge(file File) error {
 	return nil
 }
 
+// List ...
+func (s ParameterStoreService) List(file File, tags map[string]string) ([]Object, error) {
+	if err := s.ensureSession(); err != nil {
+		return []Object{}, err
+	}
+
+	svc := ssm.New(s.session)
+
+	path := strings.TrimRight(file.RemoteKey, "/")
+	if len(path) == 0 {
+		path = "/"
+	}
+
+	objects := []Object{}
+
+	// DescribeParameters is used instead of GetParametersByPath to
+	// avoid decrypting values and to include the KMS key ids.
+	if err := svc.DescribeParametersPages(&ssm.DescribeParametersInput{
+		MaxResults: aws.Int64(50),
+		ParameterFilters: []*ssm.ParameterStringFilter{{
+			Key:    aws.String("Path"),
+			Option: aws.String("Recursive"),
+			Values: []*string{aws.String(path)},
+		}},
+	}, func(page *ssm.DescribeParametersOutput, lastPage bool) bool {
+		for _, p := range page.Parameters {
+			o := Object{
+				Key:  aws.StringValue(p.Name),
+				Type: aws.StringValue(p.Type),
+			}
+
+			if p.KeyId != nil {
+				o.KMSKeyID = strings.Replace(*p.KeyId, "alias/", "", 1)
+			}
+
+			if p.LastModifiedDate != nil {
+				o.LastModified = *p.LastModifiedDate
+			}
+
+			objects = append(objects, o)
+		}
+
+		return true
+	}); err != nil {
+		return objects, err
+	}
+
+	if len(tags) == 0 {
+		return sortObjects(objects), nil
+	}
+
+	tagged := []Object{}
+	for _, o := range objects {
+		output, err := svc.ListTagsForResource(&ssm.ListTagsForResourceInput{
+			ResourceId:   aws.String(o.Key),
+			ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+		})
+		if err != nil {
+			return tagged, fmt.Errorf("%s: %s", o.Key, err)
+		}
+
+		o.Tags = map[string]string{}
+		for _, t := range output.TagList {
+			o.Tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+
+		if o.MatchesTags(tags) {
+			tagged = append(tagged, o)
+		}
+	}
+
+	return sortObjects(tagged), nil
+}
+
+// Import ...
+func (s ParameterStoreService) Import(context string, objects []Object) ([]File, error) {
+	groups := map[string][]Object{}
+
+	for _, o := range objects {
+		parent := filepath.Dir(o.Key)
+
+		groups[parent] = append(groups[parent], o)
+	}
+
+	files := []File{}
+
+	for parent, group := range groups {
+		f := File{
+			Context:   context,
+			RemoteKey: parent,
+			LocalPath: trimContext(context, parent),
+			Type:      file.TypeEnv,
+			Options:   map[string]string{},
+		}
+
+		// The most common type becomes the file type and the
+		// remaining parameters are overridden individually.
+		counts := map[string]int{}
+		for _, o := range group {
+			counts[o.Type]++
+		}
+
+		pType := PSTypeDefault
+		for t, c := range counts {
+			if c > counts[pType] {
+				pType = t
+			}
+		}
+
+		if pType != PSTypeDefault {
+			f.Options[PSTypeOption] = pType
+		}
+
+		overrides := []string{}
+		for _, o := range group {
+			f.AddKey(o.Key)
+
+			if o.Type != pType {
+				overrides = append(overrides, fmt.Sprintf("%s=%s", filepath.Base(o.Key), o.Type))
+			}
+
+			if o.Type == ssm.ParameterTypeSecureString {
+				f.Options[KMSKeyIDOption] = PSKMSKeyIDDefault
+
+				if len(o.KMSKeyID) > 0 {
+					f.Options[KMSKeyIDOption] = o.KMSKeyID
+				}
+			}
+		}
+
+		if len(overrides) > 0 {
+			sort.Strings(overrides)
+			f.Options[PSTypesOption] = strings.Join(overrides, ",")
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
 func init() {
 	s := new(ParameterStoreService)
 
@@ -576,11 +1206,42 @@ type param struct {
 	pType string
 	keyID string
 
+	tier     string
+	policies []string
+	tags     map[string]string
+
 	lastModified time.Time
 
 	arn string
 }
 
 func (p param) Changed(rp param) bool {
-	return p.value != rp.value || rp.pType != p.pType || rp.keyID != p.keyID
+	if p.value != rp.value || rp.pType != p.pType {
+		return true
+	}
+
+	// Only encrypted parameters have keys. Parameters without a key
+	// are encrypted by the default key.
+	if p.pType == ssm.ParameterTypeSecureString {
+		keyID := p.keyID
+		if len(keyID) == 0 {
+			keyID = PSKMSKeyIDDefault
+		}
+
+		if rp.keyID != keyID {
+			return true
+		}
+	}
+
+	// Parameters cannot be moved back to the standard tier, and
+	// intelligent tiering is resolved by the service.
+	if p.tier == ssm.ParameterTierAdvanced && rp.tier == ssm.ParameterTierStandard {
+		return true
+	}
+
+	local := make([]string, len(p.policies))
+	copy(local, p.policies)
+	sort.Strings(local)
+
+	return strings.Join(local, ",") != strings.Join(rp.policies, ",")
 }
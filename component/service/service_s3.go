@@ -18,8 +18,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/dabblebox/stash/component/file"
 	"github.com/dabblebox/stash/component/format"
 	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service/aws/iac"
 	awskms "github.com/dabblebox/stash/component/service/aws/kms"
 	"github.com/dabblebox/stash/component/service/aws/policy"
 	"github.com/dabblebox/stash/component/service/aws/role"
@@ -87,8 +89,22 @@ func (s *S3Service) PreHook(io IO) error {
 	return nil
 }
 
+// objectKey returns the tracked object key allowing objects that were
+// not stored under the current context, i.e. imported, to be used.
+// Keys under the context follow the file path; so, a moved file is
+// stored at its new key.
+func objectKey(file File) string {
+	if len(file.Keys) == 1 && !strings.HasPrefix(strings.TrimLeft(file.Keys[0], "/"), file.Context+"/") {
+		return file.Keys[0]
+	}
+
+	return file.RemoteKey
+}
+
 // Sync ...
 func (s *S3Service) Sync(file File) (File, error) {
+	file.RemoteKey = objectKey(file)
+
 	if len(file.Data) == 0 {
 		return file, s.Purge(file)
 	}
@@ -225,6 +241,7 @@ func parseTags(input string) map[string]string {
 
 // Download ...
 func (s *S3Service) Download(file File, format string) (File, error) {
+	file.RemoteKey = objectKey(file)
 
 	if err := s.ensureSession(); err != nil {
 		return file, err
@@ -232,42 +249,52 @@ func (s *S3Service) Download(file File, format string) (File, error) {
 
 	bucket := file.Options[S3BucketOption]
 
+	file.ARNs = map[string]string{
+		file.RemoteKey: fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, file.RemoteKey),
+	}
+
 	svc := s3.New(s.session)
 
 	switch format {
 	case output.TypeTerraform:
-
-		dir := filepath.Dir(file.LocalPath)
-
-		filePath := fmt.Sprintf("%s/kms.tf", dir)
-		printFile(filePath, s.io.Stderr)
-
-		if err := terraform.EnsureTFKMSFile(filePath, blankDefault(file.Options[KMSKeyIDOption], S3KMSKeyIDDefault)); err != nil {
-			return file, err
-		}
-
-		filePath = fmt.Sprintf("%s/%s.variables.tf", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
-
-		if err := terraform.EnsureTFVariablesFile(filePath); err != nil {
-			return file, err
-		}
-
-		filePath = fmt.Sprintf("%s/%s.auto.tfvars", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
-
-		if err := terraform.EnsureTFVarsFile(filePath, terraform.Dep{
+		artifacts, err := tfArtifacts(true, blankDefault(file.Options[KMSKeyIDOption], S3KMSKeyIDDefault), terraform.Dep{
 			Session: s.session,
 			Stdin:   s.io.Stdin,
 			Stdout:  s.io.Stdout,
 			Stderr:  s.io.Stderr,
-		}); err != nil {
+		})
+		if err != nil {
 			return file, err
 		}
 
-		d, err := s.terraform(file, bucket, s.io)
+		b, err := s.terraformBucket(bucket)
+		if err != nil {
+			return file, err
+		}
+
+		file.Artifacts = append(artifacts, Artifact{
+			Name: fmt.Sprintf("%s-buckets.tf", s.Key()),
+			ID:   bucket,
+			Data: b,
+		})
+
+		d, err := s.terraform(s.access(file, bucket))
+		file.Data = d
+		return file, err
+	case output.TypeTerraformResources:
+		d, err := s.terraformResources(s.access(file, bucket), file)
+		file.Data = d
+		return file, err
+	case output.TypeCloudFormation, output.TypePulumi, output.TypeIAMPolicy:
+		d, err := renderIaC(format, s.access(file, bucket), file)
 		file.Data = d
 		return file, err
+	case output.TypeECSContainerDef:
+		d, err := containerEnvironmentFile(file, bucket)
+		file.Data = d
+		return file, err
+	case output.TypeK8sExternalSecret:
+		return file, fmt.Errorf("%s output not supported by %s, use %s", format, s.Key(), output.TypeK8sSecretFile)
 	case output.TypeECSTaskInjectJson:
 		type EnvFileFormat struct {
 			Type  string `json:"type"`
@@ -312,16 +339,56 @@ func (s *S3Service) Download(file File, format string) (File, error) {
 	return file, nil
 }
 
-func (s S3Service) terraform(file File, bucket string, io IO) ([]byte, error) {
+func (s S3Service) terraformResources(a access, file File) ([]byte, error) {
+
+	tmpl, err := template.New("s3").Parse(awsS3.HCLResourcesTemplate)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	o := a.Objects[0]
+
+	var hcl bytes.Buffer
+	if err := tmpl.Execute(&hcl, awsS3.HCLResourcesModel{
+		Name:      o.Name,
+		Bucket:    o.Bucket,
+		Key:       o.Key,
+		KMSKeyID:  o.KMSKeyID,
+		Variables: tfVariables(file),
+	}); err != nil {
+		return []byte{}, err
+	}
+
+	return hcl.Bytes(), nil
+}
+
+// access collects the infrastructure data for the object.
+func (s S3Service) access(file File, bucket string) access {
+	arn := fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, file.RemoteKey)
+
+	return access{
+		Name: format.TerraformResourceName(file.RemoteKey),
+		Policy: policy.New(policy.Statement{
+			Effect:   "Allow",
+			Action:   []string{"s3:GetObject"},
+			Resource: []string{arn},
+		}),
+		Objects: []iac.Resource{{
+			Name:     format.TerraformResourceName(file.RemoteKey),
+			Key:      file.RemoteKey,
+			ARN:      arn,
+			Bucket:   bucket,
+			KMSKeyID: tfKMSKeyID(optionDefault(file, KMSKeyIDOption, S3KMSKeyIDDefault)),
+		}},
+	}
+}
+
+func (s S3Service) terraform(a access) ([]byte, error) {
 
 	var hcl bytes.Buffer
 	w := bufio.NewWriter(&hcl)
 
-	p, err := json.MarshalIndent(policy.New(policy.Statement{
-		Effect:   "Allow",
-		Action:   []string{"s3:Get*"},
-		Resource: []string{fmt.Sprintf("arn:aws:s3:::%s/%s", file.Options[S3BucketOption], file.RemoteKey)},
-	}), "", "    ")
+	p, err := json.MarshalIndent(a.Policy, "", "    ")
 	if err != nil {
 		return []byte{}, err
 	}
@@ -332,38 +399,60 @@ func (s S3Service) terraform(file File, bucket string, io IO) ([]byte, error) {
 	}
 
 	if err := tmpl.Execute(w, role.HCLModel{
-		Name:   format.TerraformResourceName(filepath.Base(file.RemoteKey)),
+		Name:   a.Name,
 		Policy: string(p),
 	}); err != nil {
 		return []byte{}, err
 	}
 
-	if _, err := w.WriteString("\n\n"); err != nil {
-		return []byte{}, err
-	}
+	w.Flush()
+
+	return hcl.Bytes(), nil
+}
+
+func (s S3Service) terraformBucket(bucket string) ([]byte, error) {
 
-	tmpl, err = template.New("s3").Parse(awsS3.HCLTemplate)
+	tmpl, err := template.New("s3").Parse(awsS3.HCLTemplate)
 	if err != nil {
 		return []byte{}, err
 	}
 
-	if err := tmpl.Execute(w, awsS3.HCLModel{
+	var hcl bytes.Buffer
+	if err := tmpl.Execute(&hcl, awsS3.HCLModel{
+		Name:   format.TerraformResourceName(bucket),
 		Bucket: bucket,
 	}); err != nil {
 		return []byte{}, err
 	}
 
-	w.Flush()
-
 	return hcl.Bytes(), nil
 }
 
+// containerEnvironmentFile loads the object into the container
+// environment. ECS only supports env files.
+func containerEnvironmentFile(f File, bucket string) ([]byte, error) {
+	if f.Type != file.TypeEnv {
+		return []byte{}, fmt.Errorf("%s output does not support %s files", output.TypeECSContainerDef, f.Type)
+	}
+
+	def := output.ECSContainerDefinition{
+		EnvironmentFiles: []output.ECSEnvironmentFile{{
+			Value: fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, f.RemoteKey),
+			Type:  "s3",
+		}},
+	}
+
+	return def.Marshal()
+}
+
 // Purge ...
 func (s *S3Service) Purge(file File) error {
 	if err := s.ensureSession(); err != nil {
 		return err
 	}
 
+	file.RemoteKey = objectKey(file)
+
 	bucket := file.Options[S3BucketOption]
 
 	svc := s3.New(s.session)
@@ -376,6 +465,110 @@ func (s *S3Service) Purge(file File) error {
 	return err
 }
 
+// List ...
+func (s *S3Service) List(file File, tags map[string]string) ([]Object, error) {
+	if err := s.ensureSession(); err != nil {
+		return []Object{}, err
+	}
+
+	bucket := file.Options[S3BucketOption]
+	if len(bucket) == 0 {
+		return []Object{}, fmt.Errorf("%s required", S3BucketOption)
+	}
+
+	svc := s3.New(s.session)
+
+	objects := []Object{}
+
+	if err := svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(objectKey(file)),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			if strings.HasSuffix(aws.StringValue(o.Key), "/") {
+				continue
+			}
+
+			objects = append(objects, Object{
+				Key:          aws.StringValue(o.Key),
+				ARN:          fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, aws.StringValue(o.Key)),
+				LastModified: aws.TimeValue(o.LastModified),
+			})
+		}
+
+		return true
+	}); err != nil {
+		return objects, err
+	}
+
+	listed := []Object{}
+	for _, o := range objects {
+		h, err := svc.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(o.Key),
+		})
+		if err != nil {
+			return listed, fmt.Errorf("%s: %s", o.Key, err)
+		}
+
+		if aws.StringValue(h.ServerSideEncryption) == s3.ServerSideEncryptionAwsKms {
+			o.KMSKeyID = aws.StringValue(h.SSEKMSKeyId)
+		}
+
+		if len(tags) > 0 {
+			t, err := svc.GetObjectTagging(&s3.GetObjectTaggingInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(o.Key),
+			})
+			if err != nil {
+				return listed, fmt.Errorf("%s: %s", o.Key, err)
+			}
+
+			o.Tags = map[string]string{}
+			for _, tag := range t.TagSet {
+				o.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+
+			if !o.MatchesTags(tags) {
+				continue
+			}
+		}
+
+		listed = append(listed, o)
+	}
+
+	return sortObjects(listed), nil
+}
+
+// Import ...
+func (s *S3Service) Import(context string, objects []Object) ([]File, error) {
+	files := []File{}
+
+	for _, o := range objects {
+		bucket := strings.SplitN(strings.TrimPrefix(o.ARN, "arn:aws:s3:::"), "/", 2)[0]
+
+		f := File{
+			Context:   context,
+			RemoteKey: o.Key,
+			LocalPath: trimContext(context, o.Key),
+			Type:      strings.TrimLeft(filepath.Ext(o.Key), "."),
+			Keys:      []string{o.Key},
+			Options: map[string]string{
+				S3BucketOption: bucket,
+				KMSKeyIDOption: S3KMSKeyIDDefault,
+			},
+		}
+
+		if len(o.KMSKeyID) > 0 {
+			f.Options[KMSKeyIDOption] = o.KMSKeyID
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
 func init() {
 	s := new(S3Service)
 
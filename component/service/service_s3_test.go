@@ -0,0 +1,45 @@
+package service
+
+import "testing"
+
+func TestObjectKey(t *testing.T) {
+	cases := []struct {
+		name     string
+		file     File
+		expected string
+	}{
+		{
+			name:     "synced",
+			file:     File{Context: "app", RemoteKey: "app/config/.env", Keys: []string{"app/config/.env"}},
+			expected: "app/config/.env",
+		},
+		{
+			name:     "moved",
+			file:     File{Context: "app", RemoteKey: "app/settings/.env", Keys: []string{"app/config/.env"}},
+			expected: "app/settings/.env",
+		},
+		{
+			name:     "imported",
+			file:     File{Context: "app", RemoteKey: "app/.env", Keys: []string{"legacy/.env"}},
+			expected: "legacy/.env",
+		},
+		{
+			name:     "similar context",
+			file:     File{Context: "app", RemoteKey: "app/.env", Keys: []string{"app-legacy/.env"}},
+			expected: "app-legacy/.env",
+		},
+		{
+			name:     "new",
+			file:     File{Context: "app", RemoteKey: "app/.env"},
+			expected: "app/.env",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := objectKey(c.file); actual != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, actual)
+			}
+		})
+	}
+}
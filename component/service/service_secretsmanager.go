@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"text/template"
@@ -22,6 +23,7 @@ import (
 	"github.com/dabblebox/stash/component/file"
 	"github.com/dabblebox/stash/component/format"
 	"github.com/dabblebox/stash/component/output"
+	"github.com/dabblebox/stash/component/service/aws/iac"
 	awskms "github.com/dabblebox/stash/component/service/aws/kms"
 	"github.com/dabblebox/stash/component/service/aws/policy"
 	"github.com/dabblebox/stash/component/service/aws/role"
@@ -43,6 +45,8 @@ const (
 	SMSecretsMultiple = "multiple"
 
 	SMDelimiterOption = "group_delimiter"
+
+	SMSecretStoreDefault = "aws-secrets-manager"
 )
 
 var SMSecretsOptions = []string{
@@ -103,6 +107,7 @@ func (s *SecretsManagerService) Compatible(types []string) bool {
 		file.TypeSQL:        true,
 		file.TypeYML:        true,
 		file.TypeYAML:       true,
+		file.TypeProperties: true,
 		file.TypeMissing:    true, // id_rsa private keys
 	}
 
@@ -346,43 +351,67 @@ func (s *SecretsManagerService) Download(file File, format string) (File, error)
 		}
 	}
 
-	if format == output.TypeTerraform {
-		dir := filepath.Dir(file.LocalPath)
+	file.ARNs = map[string]string{}
+	for k, v := range m {
+		file.ARNs[k] = v.ARN
+	}
 
-		filePath := fmt.Sprintf("%s/kms.tf", dir)
-		printFile(filePath, s.io.Stderr)
+	if format == output.TypeTerraform {
+		artifacts, err := tfArtifacts(true, blankDefault(file.Options[KMSKeyIDOption], SMKMSKeyIDDefault), terraform.Dep{
+			Session: s.session,
+			Stdin:   s.io.Stdin,
+			Stdout:  s.io.Stdout,
+			Stderr:  s.io.Stderr,
+		})
+		if err != nil {
+			return file, err
+		}
 
-		if err := terraform.EnsureTFKMSFile(filePath, blankDefault(file.Options[KMSKeyIDOption], SMKMSKeyIDDefault)); err != nil {
+		p, err := terraform.SMPolicy()
+		if err != nil {
 			return file, err
 		}
 
-		filePath = fmt.Sprintf("%s/%s-policy.tf", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
+		file.Artifacts = append(artifacts, Artifact{
+			Name: fmt.Sprintf("%s-policy.tf", s.Key()),
+			ID:   "policy",
+			Data: p,
+		})
 
-		if err := terraform.EnsureTFSMPolicyFile(filePath); err != nil {
+		d, err := s.terraform(s.access(m, file))
+		if err != nil {
 			return file, err
 		}
 
-		filePath = fmt.Sprintf("%s/%s.variables.tf", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
+		file.Data = d
+	} else if format == output.TypeTerraformResources {
+		d, err := s.terraformResources(s.access(m, file), file)
+		if err != nil {
+			return file, err
+		}
 
-		if err := terraform.EnsureTFVariablesFile(filePath); err != nil {
+		file.Data = d
+	} else if isIaC(format) {
+		d, err := renderIaC(format, s.access(m, file), file)
+		if err != nil {
 			return file, err
 		}
 
-		filePath = fmt.Sprintf("%s/%s.auto.tfvars", dir, s.Key())
-		printFile(filePath, s.io.Stderr)
+		file.Data = d
+	} else if format == output.TypeECSContainerDef {
+		d, err := containerDefinition(m, file)
+		if err != nil {
+			return file, err
+		}
 
-		if err := terraform.EnsureTFVarsFile(filePath, terraform.Dep{
-			Session: s.session,
-			Stdin:   s.io.Stdin,
-			Stdout:  s.io.Stdout,
-			Stderr:  s.io.Stderr,
-		}); err != nil {
+		file.Data = d
+	} else if format == output.TypeK8sExternalSecret {
+		refs, err := externalSecretRefs(m, file)
+		if err != nil {
 			return file, err
 		}
 
-		d, err := s.terraform(m, file, s.io)
+		d, err := output.ExternalSecret(output.KubernetesName(file.LocalPath), k8sSecretStore(file, SMSecretStoreDefault), refs)
 		if err != nil {
 			return file, err
 		}
@@ -400,26 +429,47 @@ func (s *SecretsManagerService) Download(file File, format string) (File, error)
 	return file, nil
 }
 
-func (s SecretsManagerService) terraform(m map[string]value, file File, io IO) ([]byte, error) {
+// access collects the infrastructure data for the secrets.
+func (s SecretsManagerService) access(m map[string]value, file File) access {
 
-	var hcl bytes.Buffer
-	w := bufio.NewWriter(&hcl)
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	a := access{
+		Name: format.TerraformResourceName(file.RemoteKey),
+	}
 
-	arnsMap := map[string]string{}
 	arns := []string{}
 
-	for _, value := range m {
-		key := format.TerraformResourceName(filepath.Base(value.ARN))
+	for _, k := range keys {
+		a.Secrets = append(a.Secrets, iac.Resource{
+			Name:     format.TerraformResourceName(k),
+			Key:      k,
+			ARN:      m[k].ARN,
+			KMSKeyID: tfKMSKeyID(file.Options[KMSKeyIDOption]),
+		})
 
-		arnsMap[key] = value.ARN
-		arns = append(arns, value.ARN)
+		arns = append(arns, m[k].ARN)
 	}
 
-	p, err := json.MarshalIndent(policy.New(policy.Statement{
+	a.Policy = policy.New(policy.Statement{
 		Effect:   "Allow",
 		Action:   []string{"secretsmanager:GetSecretValue"},
 		Resource: &arns,
-	}), "", "    ")
+	})
+
+	return a
+}
+
+func (s SecretsManagerService) terraform(a access) ([]byte, error) {
+
+	var hcl bytes.Buffer
+	w := bufio.NewWriter(&hcl)
+
+	p, err := json.MarshalIndent(a.Policy, "", "    ")
 	if err != nil {
 		return []byte{}, err
 	}
@@ -430,7 +480,7 @@ func (s SecretsManagerService) terraform(m map[string]value, file File, io IO) (
 	}
 
 	if err := tmpl.Execute(w, role.HCLModel{
-		Name:   format.TerraformResourceName(file.RemoteKey),
+		Name:   a.Name,
 		Policy: string(p),
 	}); err != nil {
 		return []byte{}, err
@@ -438,13 +488,13 @@ func (s SecretsManagerService) terraform(m map[string]value, file File, io IO) (
 
 	w.WriteString("\n\n")
 
-	tmpl, err = template.New("sm").Funcs(template.FuncMap{"notLast": sm.NotLast}).Parse(sm.HCLSecretsTemplate)
+	tmpl, err = template.New("sm").Parse(sm.HCLSecretPoliciesTemplate)
 	if err != nil {
 		return []byte{}, err
 	}
 
 	if err := tmpl.Execute(w, sm.HCLModel{
-		Arns: arnsMap,
+		Secrets: a.Secrets,
 	}); err != nil {
 		return []byte{}, err
 	}
@@ -454,6 +504,24 @@ func (s SecretsManagerService) terraform(m map[string]value, file File, io IO) (
 	return hcl.Bytes(), nil
 }
 
+func (s SecretsManagerService) terraformResources(a access, file File) ([]byte, error) {
+
+	tmpl, err := template.New("sm").Parse(sm.HCLResourcesTemplate)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var hcl bytes.Buffer
+	if err := tmpl.Execute(&hcl, sm.HCLResourcesModel{
+		Secrets:   a.Secrets,
+		Variables: tfVariables(file),
+	}); err != nil {
+		return []byte{}, err
+	}
+
+	return hcl.Bytes(), nil
+}
+
 // Purge ...
 func (s *SecretsManagerService) Purge(file File) error {
 
@@ -479,6 +547,128 @@ func (s *SecretsManagerService) Purge(file File) error {
 	return nil
 }
 
+// List ...
+func (s *SecretsManagerService) List(file File, tags map[string]string) ([]Object, error) {
+	if err := s.ensureSession(); err != nil {
+		return []Object{}, err
+	}
+
+	svc := secretsmanager.New(s.session)
+
+	objects := []Object{}
+
+	input := &secretsmanager.ListSecretsInput{
+		MaxResults: aws.Int64(100),
+	}
+
+	// The name filter returns secrets under the context. It ignores
+	// case; so, names are still compared below.
+	if len(file.RemoteKey) > 0 {
+		input.Filters = []*secretsmanager.Filter{{
+			Key:    aws.String(secretsmanager.FilterNameStringTypeName),
+			Values: []*string{aws.String(file.RemoteKey)},
+		}}
+	}
+
+	if err := svc.ListSecretsPages(input, func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, e := range page.SecretList {
+			if e.DeletedDate != nil || !strings.HasPrefix(aws.StringValue(e.Name), file.RemoteKey) {
+				continue
+			}
+
+			o := Object{
+				Key:      aws.StringValue(e.Name),
+				ARN:      aws.StringValue(e.ARN),
+				KMSKeyID: aws.StringValue(e.KmsKeyId),
+				Tags:     map[string]string{},
+			}
+
+			if e.LastChangedDate != nil {
+				o.LastModified = *e.LastChangedDate
+			}
+
+			for _, t := range e.Tags {
+				o.Tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+			}
+
+			if o.MatchesTags(tags) {
+				objects = append(objects, o)
+			}
+		}
+
+		return true
+	}); err != nil {
+		return objects, err
+	}
+
+	return sortObjects(objects), nil
+}
+
+// Import ...
+func (s *SecretsManagerService) Import(context string, objects []Object) ([]File, error) {
+	files := []File{}
+	groups := map[string][]Object{}
+
+	for _, o := range objects {
+		// Secrets stored separately are grouped by the parent key
+		// when the parent key looks like a file.
+		// i.e. my-app/config/dev/.env/DB
+		parent := filepath.Dir(o.Key)
+		ext := strings.TrimLeft(filepath.Ext(parent), ".")
+
+		if len(ext) > 0 && s.Compatible([]string{ext}) {
+			groups[parent] = append(groups[parent], o)
+			continue
+		}
+
+		f := File{
+			Context:   context,
+			RemoteKey: o.Key,
+			LocalPath: trimContext(context, o.Key),
+			Type:      strings.TrimLeft(filepath.Ext(o.Key), "."),
+			Keys:      []string{o.Key},
+			Options: map[string]string{
+				KMSKeyIDOption: SMKMSKeyIDDefault,
+			},
+		}
+
+		if len(o.KMSKeyID) > 0 {
+			f.Options[KMSKeyIDOption] = o.KMSKeyID
+		}
+
+		if f.SupportsParsing() {
+			f.Options[SMSecretsOption] = SMSecretsSingle
+		}
+
+		files = append(files, f)
+	}
+
+	for parent, group := range groups {
+		f := File{
+			Context:   context,
+			RemoteKey: parent,
+			LocalPath: trimContext(context, parent),
+			Type:      strings.TrimLeft(filepath.Ext(parent), "."),
+			Options: map[string]string{
+				KMSKeyIDOption:  SMKMSKeyIDDefault,
+				SMSecretsOption: SMSecretsMultiple,
+			},
+		}
+
+		for _, o := range group {
+			if len(o.KMSKeyID) > 0 {
+				f.Options[KMSKeyIDOption] = o.KMSKeyID
+			}
+
+			f.AddKey(o.Key)
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
 func init() {
 	s := &SecretsManagerService{
 		options: map[string]string{
@@ -599,6 +789,119 @@ func toData(m map[string]value, f File, format string) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// containerDefinition references each secret property using a JSON
+// key selector so the container environment matches the local file.
+func containerDefinition(m map[string]value, f File) ([]byte, error) {
+	remoteKeys := []string{}
+	for k := range m {
+		remoteKeys = append(remoteKeys, k)
+	}
+	sort.Strings(remoteKeys)
+
+	def := output.ECSContainerDefinition{}
+
+	for _, remoteKey := range remoteKeys {
+		if f.Type != file.TypeEnv && f.Type != file.TypeJSON {
+			def.Secrets = append(def.Secrets, output.ECSSecret{
+				Name:      filepath.Base(remoteKey),
+				ValueFrom: m[remoteKey].ARN,
+			})
+			continue
+		}
+
+		temp := make(map[string]json.RawMessage)
+		if err := json.Unmarshal([]byte(m[remoteKey].String()), &temp); err != nil {
+			return []byte{}, err
+		}
+
+		props := []string{}
+		for k := range temp {
+			props = append(props, k)
+		}
+		sort.Strings(props)
+
+		// Multiple JSON secrets hold one top-level property of the
+		// local file. Nested objects cannot be selected by key; so,
+		// the secret is referenced.
+		if f.Type == file.TypeJSON && f.Options[SMSecretsOption] == SMSecretsMultiple {
+			suffix := filepath.Base(remoteKey)
+
+			valueFrom := m[remoteKey].ARN
+			if _, ok := temp[suffix]; ok && len(temp) == 1 {
+				valueFrom = fmt.Sprintf("%s:%s::", m[remoteKey].ARN, suffix)
+			}
+
+			def.Secrets = append(def.Secrets, output.ECSSecret{
+				Name:      suffix,
+				ValueFrom: valueFrom,
+			})
+			continue
+		}
+
+		for _, prop := range props {
+			name := prop
+			if f.Type == file.TypeEnv {
+				name = envProp(remoteKey, prop, f.Options[SMDelimiterOption])
+			}
+
+			def.Secrets = append(def.Secrets, output.ECSSecret{
+				Name:      name,
+				ValueFrom: fmt.Sprintf("%s:%s::", m[remoteKey].ARN, prop),
+			})
+		}
+	}
+
+	return def.Marshal()
+}
+
+// externalSecretRefs references each secret property so the
+// Kubernetes secret matches the local file without exposing values.
+func externalSecretRefs(m map[string]value, f File) ([]output.ExternalSecretRef, error) {
+	remoteKeys := []string{}
+	for k := range m {
+		remoteKeys = append(remoteKeys, k)
+	}
+	sort.Strings(remoteKeys)
+
+	refs := []output.ExternalSecretRef{}
+
+	for _, remoteKey := range remoteKeys {
+		switch f.Type {
+		case file.TypeEnv:
+			temp := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(m[remoteKey].String()), &temp); err != nil {
+				return refs, err
+			}
+
+			props := []string{}
+			for k := range temp {
+				props = append(props, k)
+			}
+			sort.Strings(props)
+
+			for _, prop := range props {
+				refs = append(refs, output.ExternalSecretRef{
+					SecretKey: envProp(remoteKey, prop, f.Options[SMDelimiterOption]),
+					Key:       remoteKey,
+					Property:  prop,
+				})
+			}
+		case file.TypeJSON:
+			refs = append(refs, output.ExternalSecretRef{
+				Key:     remoteKey,
+				Extract: true,
+			})
+		default:
+			refs = append(refs, output.ExternalSecretRef{
+				SecretKey: output.KubernetesKey(f.LocalPath),
+				Key:       remoteKey,
+			})
+		}
+	}
+
+	return refs, nil
+}
+
 func envToData(m map[string]value, delimiter string) ([]byte, error) {
 	results := bytes.Buffer{}
 	for remoteKey, value := range m {
@@ -609,12 +912,7 @@ func envToData(m map[string]value, delimiter string) ([]byte, error) {
 		}
 
 		for tk, tv := range temp {
-			keySuffix := filepath.Base(remoteKey)
-
-			prop := tk
-			if len(delimiter) > 0 && strings.ToUpper(keySuffix) != tk {
-				prop = strings.Trim(fmt.Sprintf("%s%s%s", strings.ToUpper(keySuffix), delimiter, tk), delimiter)
-			}
+			prop := envProp(remoteKey, tk, delimiter)
 
 			switch value := tv.(type) {
 			case string:
@@ -630,6 +928,18 @@ func envToData(m map[string]value, delimiter string) ([]byte, error) {
 	return results.Bytes(), nil
 }
 
+// envProp returns the env variable name for a property stored in a
+// grouped secret.
+func envProp(remoteKey, prop, delimiter string) string {
+	keySuffix := filepath.Base(remoteKey)
+
+	if len(delimiter) > 0 && strings.ToUpper(keySuffix) != prop {
+		return strings.Trim(fmt.Sprintf("%s%s%s", strings.ToUpper(keySuffix), delimiter, prop), delimiter)
+	}
+
+	return prop
+}
+
 func jsonToData(m map[string]value, secrets string) ([]byte, error) {
 	if secrets != SMSecretsMultiple {
 		for _, value := range m {
@@ -706,6 +1016,10 @@ func toMap(f *File) (map[string]string, error) {
 				return map[string]string{}, err
 			}
 
+			if len(f.Keys) == 1 {
+				return map[string]string{f.Keys[0]: string(jsonProps)}, nil
+			}
+
 			return map[string]string{f.RemoteKey: string(jsonProps)}, nil
 		}
 
@@ -1,12 +1,8 @@
 package service
 
 import (
-	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/gookit/color"
 )
 
 const (
@@ -15,10 +11,6 @@ const (
 	SecurityRatingHigh   = 1
 )
 
-var (
-	fileTokenColor = color.FgCyan.Render
-)
-
 // Services ...
 var Services = map[string]IService{}
 
@@ -52,10 +44,6 @@ func ToKeys(servcies []IService) []string {
 	return keys
 }
 
-func printFile(path string, io *os.File) {
-	fmt.Fprintf(io, "  - [%s]\n", fileTokenColor(path))
-}
-
 type BySecurityRating []IService
 
 func (s BySecurityRating) Len() int           { return len(s) }
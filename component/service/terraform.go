@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dabblebox/stash/component/service/aws/terraform"
+)
+
+const (
+	// TFValuesOption determines how values are handled in the
+	// generated Terraform resources.
+	TFValuesOption    = "tf_values"
+	TFValuesIgnore    = "ignore"
+	TFValuesVariables = "variables"
+	TFValuesDefault   = TFValuesIgnore
+)
+
+const (
+	tfKMSFile       = "kms.tf"
+	tfVariablesFile = "variables.tf"
+	tfVarsFile      = "stash.auto.tfvars"
+)
+
+var TFValuesOptions = []string{
+	TFValuesIgnore,
+	TFValuesVariables,
+}
+
+// tfVariables determines if resource values are referenced through
+// Terraform variables.
+func tfVariables(file File) bool {
+	return strings.ToLower(file.Options[TFValuesOption]) == TFValuesVariables
+}
+
+// tfKMSKeyID converts a stored KMS key option into a value accepted
+// by Terraform. Aliases are stored without the "alias/" prefix.
+func tfKMSKeyID(keyID string) string {
+	if len(keyID) == 0 || strings.HasPrefix(keyID, "arn:") || strings.HasPrefix(keyID, "alias/") {
+		return keyID
+	}
+
+	if regexp.MustCompile(`^[0-9a-fA-F-]{36}$`).MatchString(keyID) {
+		return keyID
+	}
+
+	return fmt.Sprintf("alias/%s", keyID)
+}
+
+// tfArtifacts returns the module files shared by every service. The
+// KMS key is only included when the data is encrypted with KMS.
+func tfArtifacts(kms bool, kmsKeyID string, dep terraform.Dep) ([]Artifact, error) {
+	artifacts := []Artifact{}
+
+	if kms {
+		d, err := terraform.KMS(kmsKeyID)
+		if err != nil {
+			return artifacts, err
+		}
+
+		artifacts = append(artifacts, Artifact{Name: tfKMSFile, ID: "kms", Data: d})
+	}
+
+	artifacts = append(artifacts, Artifact{
+		Name: tfVariablesFile,
+		ID:   "variables",
+		Data: terraform.Variables(),
+	}, Artifact{
+		Name: tfVarsFile,
+		ID:   "tfvars",
+		Seed: func() ([]byte, error) {
+			return terraform.TFVars(dep)
+		},
+	})
+
+	return artifacts, nil
+}
@@ -4,7 +4,7 @@ go 1.13
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.0.7
-	github.com/aws/aws-sdk-go v1.31.4
+	github.com/aws/aws-sdk-go v1.34.0
 	github.com/fatih/color v1.7.0
 	github.com/gookit/color v1.2.5
 	github.com/mattn/go-colorable v0.1.6 // indirect